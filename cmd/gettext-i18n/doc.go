@@ -0,0 +1,3 @@
+// Package main provides the gettext-i18n command-line tool for converting
+// li18ngo translation message files to and from gettext po and mo files.
+package main
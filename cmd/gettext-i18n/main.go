@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/snivilised/li18ngo/tools/gettext"
+	"github.com/snivilised/li18ngo/tools/mio"
+)
+
+// convert converts the message file read from the input transport, in the
+// direction implied by the extensions of the input and output addresses.
+func convert(input, output mio.Transport, options gettext.Options) error {
+	data, err := input.Read()
+	if err != nil {
+		return errors.Wrapf(err, "error reading from transport: '%s'",
+			input.Address(),
+		)
+	}
+
+	var converted []byte
+
+	switch from, to := filepath.Ext(input.Address()), filepath.Ext(output.Address()); {
+	case from == ".json" && to == ".po":
+		converted, err = gettext.ExportPO(data, options)
+	case from == ".json" && to == ".mo":
+		converted, err = gettext.ExportMO(data, options)
+	case from == ".po" && to == ".json":
+		converted, err = gettext.ImportPO(data)
+	case from == ".mo" && to == ".json":
+		converted, err = gettext.ImportMO(data)
+	default:
+		return fmt.Errorf("unsupported conversion: '%v' to '%v'", from, to)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error converting: %v", err)
+	}
+
+	if err := output.Write(converted); err != nil {
+		return errors.Wrapf(err, "error writing to transport: '%v'",
+			output.Address(),
+		)
+	}
+
+	return nil
+}
+
+func main() {
+	path := flag.String("path", "",
+		"Path to the input file (.json, .po or .mo)",
+	)
+	out := flag.String("out", "",
+		"Path to the output file (.json, .po or .mo)",
+	)
+	source := flag.String("source", "",
+		"Path to the JSON file of the base language, whose text is presented as the msgid (export only)",
+	)
+	lang := flag.String("lang", "",
+		"Language of the translations, written to the Language header (export only)",
+	)
+
+	flag.Parse()
+
+	if *path == "" || *out == "" {
+		fmt.Println("Usage: gettext-i18n -path <path-to-input-file> -out <path-to-output-file> [-source <path-to-source-file>] [-lang <tag>]")
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	options := gettext.Options{
+		Language: *lang,
+	}
+
+	if *source != "" {
+		data, err := (&mio.NativeReaderWriterFS{Path: *source}).Read()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		options.Source = data
+	}
+
+	if err := convert(
+		&mio.NativeReaderWriterFS{Path: *path},
+		&mio.NativeReaderWriterFS{Path: *out},
+		options,
+	); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo/tools/sorter"
+)
+
+// ---------------------------------------------------------------------------
+// Check
+//
+// The check mode lints the deployed translation files against the Underliers
+// map, so that drift between the two is caught in CI, rather than surfacing
+// at run time as a message that silently falls back to its default.
+// ---------------------------------------------------------------------------
+
+// activeFilePattern matches the name of a translation file, ie
+// [<name>.]active.<lang>.json
+var activeFilePattern = regexp.MustCompile(`^(?:(.+)\.)?active\.([^.]+)\.json$`)
+
+// check reports the problems found in every translation file in the active
+// directory and returns an error if there are any.
+func check(entries []underlierEntry, o *messageFileOptions) error {
+	files, err := activeFiles(o)
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		return fmt.Errorf("no translation files found in %q", o.activeDir)
+	}
+
+	total := 0
+	for _, file := range files {
+		messages, err := readMessageFile(filepath.Join(o.activeDir, file))
+		if err != nil {
+			return err
+		}
+
+		problems := checkMessages(entries, messages)
+		if len(problems) == 0 {
+			fmt.Printf("lingo: checked %s - OK\n", file)
+			continue
+		}
+
+		fmt.Printf("lingo: checked %s - %d problem(s):\n", file, len(problems))
+		for _, p := range problems {
+			fmt.Printf("  - %s\n", p.Error())
+		}
+		total += len(problems)
+	}
+
+	if total > 0 {
+		return fmt.Errorf("%d problem(s) found in %d translation file(s)", total, len(files))
+	}
+	return nil
+}
+
+// checkMessages cross-checks the messages of a translation file with the
+// Underliers, returning the stale IDs, missing IDs, {{.Token}} mismatches
+// and hash mismatches found.
+func checkMessages(entries []underlierEntry, messages map[string]sorter.HashedMessageEntry) []error {
+	var errs []error
+	defined := make(map[string]bool, len(entries))
+
+	for _, e := range entries {
+		defined[e.MessageID] = true
+
+		translated, found := messages[e.MessageID]
+		if !found {
+			errs = append(errs, validationError{e.MessageID, "", "missing: not present in the translation file"})
+			continue
+		}
+
+		fieldNames := map[string]bool{}
+		for _, f := range e.Fields {
+			fieldNames[f.Note] = true
+		}
+
+		for _, category := range sorter.Categories {
+			for _, tok := range extractTemplateTokens(translated.Form(category)) {
+				if !fieldNames[tok] {
+					errs = append(errs, validationError{e.MessageID, tok,
+						fmt.Sprintf("{{.%s}} in %s has no matching Fields entry", tok, category)})
+				}
+			}
+		}
+
+		used := map[string]bool{}
+		for _, tok := range extractTemplateTokens(translated.Other) {
+			used[tok] = true
+		}
+		for _, tok := range extractTemplateTokens(e.Other) {
+			if translated.Other != "" && !used[tok] {
+				errs = append(errs, validationError{e.MessageID, tok,
+					fmt.Sprintf("{{.%s}} of the source is missing from other", tok)})
+			}
+		}
+
+		if current := hash(e); translated.Hash != "" && translated.Hash != current {
+			errs = append(errs, validationError{e.MessageID, "", fmt.Sprintf(
+				"hash %s does not match %s, the source has changed since it was translated",
+				translated.Hash, current)})
+		}
+	}
+
+	stale := make([]string, 0, len(messages))
+	for id := range messages {
+		if !defined[id] {
+			stale = append(stale, id)
+		}
+	}
+	sort.Strings(stale)
+	for _, id := range stale {
+		errs = append(errs, validationError{id, "", "stale: not defined in the Underliers"})
+	}
+
+	return errs
+}
+
+// activeFiles returns the names of the translation files in the active
+// directory, restricted to those of the name prefix, if specified.
+func activeFiles(o *messageFileOptions) ([]string, error) {
+	entries, err := os.ReadDir(o.activeDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading translation files: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		matches := activeFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || matches == nil || (o.name != "" && matches[1] != o.name) {
+			continue
+		}
+		if _, err := language.Parse(matches[2]); err != nil {
+			continue
+		}
+		files = append(files, entry.Name())
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/snivilised/li18ngo/tools/sorter"
+)
+
+var _ = Describe("Check", func() {
+	var (
+		entries  []underlierEntry
+		messages map[string]sorter.HashedMessageEntry
+	)
+
+	BeforeEach(func() {
+		entries = []underlierEntry{
+			{
+				MessageID:   "localisation",
+				Description: "Localisation",
+				Other:       "localisation",
+			},
+			{
+				MessageID:   "using-config-file",
+				Description: "Message to indicate which config is being used",
+				Other:       "Using config file: {{.ConfigFileName}}",
+				Fields:      []fieldEntry{{Note: "ConfigFileName", GoType: "string"}},
+			},
+		}
+		messages = map[string]sorter.HashedMessageEntry{
+			"localisation": {
+				MessageEntry: sorter.MessageEntry{Description: "Localisation", Other: "localisation (fr)"},
+				Hash:         hash(entries[0]),
+			},
+			"using-config-file": {
+				MessageEntry: sorter.MessageEntry{
+					Description: entries[1].Description,
+					Other:       "Utilisation du fichier de configuration: {{.ConfigFileName}}",
+				},
+				Hash: hash(entries[1]),
+			},
+		}
+	})
+
+	Context("checkMessages", func() {
+		When("translations match the Underliers", func() {
+			It("🧪 should: report no problems", func() {
+				Expect(checkMessages(entries, messages)).To(BeEmpty())
+			})
+		})
+
+		When("translation is not defined in the Underliers", func() {
+			It("🧪 should: report stale id", func() {
+				messages["removed"] = sorter.HashedMessageEntry{
+					MessageEntry: sorter.MessageEntry{Other: "supprimé"},
+				}
+
+				Expect(checkMessages(entries, messages)).To(Equal([]error{
+					validationError{"removed", "", "stale: not defined in the Underliers"},
+				}))
+			})
+		})
+
+		When("Underlier is not translated", func() {
+			It("🧪 should: report missing id", func() {
+				delete(messages, "localisation")
+
+				Expect(checkMessages(entries, messages)).To(Equal([]error{
+					validationError{"localisation", "", "missing: not present in the translation file"},
+				}))
+			})
+		})
+
+		When("tokens of the translation do not match those of the source", func() {
+			It("🧪 should: report token mismatch", func() {
+				translated := messages["using-config-file"]
+				translated.Other = "Utilisation du fichier de configuration: {{.ConfigFile}}"
+				messages["using-config-file"] = translated
+
+				Expect(checkMessages(entries, messages)).To(Equal([]error{
+					validationError{"using-config-file", "ConfigFile",
+						"{{.ConfigFile}} in other has no matching Fields entry"},
+					validationError{"using-config-file", "ConfigFileName",
+						"{{.ConfigFileName}} of the source is missing from other"},
+				}))
+			})
+		})
+
+		When("source has changed since it was translated", func() {
+			It("🧪 should: report hash mismatch", func() {
+				entries[0].Other = "localisation, revised"
+
+				Expect(checkMessages(entries, messages)).To(Equal([]error{
+					validationError{"localisation", "", "hash " + messages["localisation"].Hash +
+						" does not match " + hash(entries[0]) +
+						", the source has changed since it was translated"},
+				}))
+			})
+		})
+	})
+
+	Context("check", func() {
+		var o *messageFileOptions
+
+		BeforeEach(func() {
+			o = &messageFileOptions{
+				activeDir: GinkgoT().TempDir(),
+				name:      "arcadia",
+			}
+		})
+
+		It("🧪 should: succeed when there are no problems", func() {
+			Expect(writeMessageFile(filepath.Join(o.activeDir, "arcadia.active.fr.json"), messages)).To(Succeed())
+
+			Expect(check(entries, o)).To(Succeed())
+		})
+
+		It("🧪 should: fail when there are problems", func() {
+			delete(messages, "localisation")
+			Expect(writeMessageFile(filepath.Join(o.activeDir, "arcadia.active.fr.json"), messages)).To(Succeed())
+
+			Expect(check(entries, o)).To(MatchError("1 problem(s) found in 1 translation file(s)"))
+		})
+
+		It("🧪 should: only check files of the name", func() {
+			delete(messages, "localisation")
+			Expect(writeMessageFile(filepath.Join(o.activeDir, "other.active.fr.json"), messages)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(o.activeDir, "arcadia.active.de.json"), []byte(`{
+  "localisation": {"description": "Localisation", "other": "Lokalisierung"},
+  "using-config-file": {"description": "", "other": "Konfigurationsdatei: {{.ConfigFileName}}"}
+}`), 0o600)).To(Succeed())
+
+			Expect(check(entries, o)).To(Succeed())
+		})
+	})
+})
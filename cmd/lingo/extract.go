@@ -0,0 +1,263 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo/internal/third/lo"
+	"github.com/snivilised/li18ngo/tools/sorter"
+	"github.com/snivilised/li18ngo/tools/stale"
+)
+
+// ---------------------------------------------------------------------------
+// Extract and merge
+//
+// These modes replace goi18n extract and goi18n merge. Rather than scanning
+// the source for message literals, the messages are taken directly from the
+// Underliers map, which is the single source of truth. The files produced
+// are identical in form to those of goi18n, followed by the sorter: extract
+// writes active.<base>.json and merge writes translate.<lang>.json for each
+// language, whose hashes are compatible with goi18n.
+// ---------------------------------------------------------------------------
+
+// messageFileOptions controls the naming and location of message files.
+type messageFileOptions struct {
+	// outDir is the directory message files are written to
+	outDir string
+
+	// activeDir is the directory containing the existing translation files
+	activeDir string
+
+	// name is the optional prefix of message file names, eg <name>.active.en-US.json
+	name string
+
+	// base is the language the messages are authored in
+	base language.Tag
+
+	// languages to merge; if empty, those of the existing translation files
+	languages []language.Tag
+}
+
+// activeFileName returns the name of the active message file of the
+// language, ie [<name>.]active.<lang>.json.
+func (o *messageFileOptions) activeFileName(tag language.Tag) string {
+	if o.name == "" {
+		return fmt.Sprintf("active.%s.json", tag)
+	}
+	return fmt.Sprintf("%s.active.%s.json", o.name, tag)
+}
+
+// extract writes the messages of the Underliers, in the base language, to
+// active.<base>.json.
+func extract(entries []underlierEntry, o *messageFileOptions) error {
+	messages := make(map[string]sorter.MessageEntry, len(entries))
+
+	for _, e := range entries {
+		messages[e.MessageID] = sourceMessage(e)
+	}
+
+	return writeMessageFile(filepath.Join(o.outDir, o.activeFileName(o.base)), messages)
+}
+
+// merge writes translate.<lang>.json for each language, containing the
+// messages that have not been translated, or whose source has changed since
+// they were translated, as denoted by the hash.
+func merge(entries []underlierEntry, o *messageFileOptions) error {
+	languages := o.languages
+	if len(languages) == 0 {
+		found, err := activeLanguages(o)
+		if err != nil {
+			return err
+		}
+		languages = found
+	}
+
+	if len(languages) == 0 {
+		return fmt.Errorf("no languages to merge; use --lang or provide translation files in %q", o.activeDir)
+	}
+
+	for _, tag := range languages {
+		if tag == o.base {
+			continue
+		}
+
+		active, err := readMessageFile(filepath.Join(o.activeDir, o.activeFileName(tag)))
+		if err != nil {
+			return err
+		}
+
+		translate := map[string]sorter.HashedMessageEntry{}
+		categories := pluralCategories(tag)
+
+		for _, e := range entries {
+			source := sourceMessage(e)
+			required := []string{"other"}
+			if source.IsPlural() {
+				required = categories
+			}
+
+			if translated, found := active[e.MessageID]; found && isTranslated(&translated, hash(e), required) {
+				continue
+			}
+
+			pending := sorter.HashedMessageEntry{
+				MessageEntry: sorter.MessageEntry{Description: e.Description},
+				Hash:         hash(e),
+			}
+			for _, c := range required {
+				// as for goi18n, a category the source does not define is
+				// given its other form, for the translator to complete
+				pending.SetForm(c, lo.Ternary(source.Form(c) != "", source.Form(c), source.Other))
+			}
+			translate[e.MessageID] = pending
+		}
+
+		// the name only identifies the active files; the translate file is
+		// always translate.<lang>.json, as expected by xliff-i18n
+		if err := writeMessageFile(filepath.Join(o.outDir, fmt.Sprintf("translate.%s.json", tag)), translate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sourceMessage returns the message entry of the Underlier, as it appears
+// in the generated message, ie with the Format of its fields applied.
+func sourceMessage(e underlierEntry) sorter.MessageEntry {
+	return sorter.MessageEntry{
+		Description: e.Description,
+		Other:       e.formatted(e.Other),
+		Zero:        e.formatted(e.Zero),
+		One:         e.formatted(e.One),
+		Two:         e.formatted(e.Two),
+		Few:         e.formatted(e.Few),
+		Many:        e.formatted(e.Many),
+	}
+}
+
+// hash returns the hash of the message, in the same manner as goi18n, so
+// that translation files produced by either are interchangeable.
+func hash(e underlierEntry) string {
+	return stale.Hash(sourceMessage(e))
+}
+
+// isTranslated determines whether the translation is of the current source
+// and defines the required plural forms. An empty hash is accepted, for
+// compatibility with translations that pre-date hashing.
+func isTranslated(translated *sorter.HashedMessageEntry, hash string, required []string) bool {
+	if translated.Hash != "" && translated.Hash != hash {
+		return false
+	}
+	for _, c := range required {
+		if translated.Form(c) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// pluralCategories returns the plural categories of the language, in
+// canonical order, by probing its cardinal plural rules with a range of
+// integers and decimals.
+func pluralCategories(tag language.Tag) []string {
+	forms := map[plural.Form]string{
+		plural.Zero: "zero", plural.One: "one", plural.Two: "two",
+		plural.Few: "few", plural.Many: "many", plural.Other: "other",
+	}
+	found := map[string]bool{"other": true}
+
+	for i := 0; i <= 1000; i++ {
+		found[forms[plural.Cardinal.MatchPlural(tag, i, 0, 0, 0, 0)]] = true
+	}
+	for _, i := range []int{10_000, 100_000, 1_000_000, 10_000_000} {
+		found[forms[plural.Cardinal.MatchPlural(tag, i, 0, 0, 0, 0)]] = true
+	}
+	for i := 0; i <= 10; i++ {
+		for f := 1; f <= 9; f++ {
+			found[forms[plural.Cardinal.MatchPlural(tag, i, 1, 1, f, f)]] = true
+		}
+	}
+
+	var categories []string
+	for _, c := range sorter.Categories {
+		if found[c] {
+			categories = append(categories, c)
+		}
+	}
+	return categories
+}
+
+// activeLanguages returns the languages of the translation files in the
+// active directory, other than the base language.
+func activeLanguages(o *messageFileOptions) ([]language.Tag, error) {
+	entries, err := os.ReadDir(o.activeDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading translation files: %w", err)
+	}
+
+	prefix := strings.TrimSuffix(o.activeFileName(language.Und), "und.json")
+
+	var languages []language.Tag
+	for _, entry := range entries {
+		raw, found := strings.CutPrefix(entry.Name(), prefix)
+		if entry.IsDir() || !found || !strings.HasSuffix(raw, ".json") {
+			continue
+		}
+		if tag, err := language.Parse(strings.TrimSuffix(raw, ".json")); err == nil && tag != o.base {
+			languages = append(languages, tag)
+		}
+	}
+
+	sort.Slice(languages, func(i, j int) bool {
+		return languages[i].String() < languages[j].String()
+	})
+	return languages, nil
+}
+
+// readMessageFile reads the translation file; a file that does not exist
+// contains no translations.
+func readMessageFile(path string) (map[string]sorter.HashedMessageEntry, error) {
+	messages := map[string]sorter.HashedMessageEntry{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return messages, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading translation file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("parsing translation file %q: %w", path, err)
+	}
+	return messages, nil
+}
+
+// writeMessageFile writes the messages in the same form as the sorter, ie
+// ordered by message id.
+func writeMessageFile[T sorter.MessageEntry | sorter.HashedMessageEntry](path string, messages map[string]T) error {
+	data, err := json.MarshalIndent(messages, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating output dir: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil { //nolint:gosec // translation files are not sensitive
+		return fmt.Errorf("writing %q: %w", path, err)
+	}
+
+	fmt.Printf("lingo: wrote %s\n", path)
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+)
+
+var _ = Describe("Merge", func() {
+	var (
+		o       *messageFileOptions
+		entries []underlierEntry
+	)
+
+	BeforeEach(func() {
+		o = &messageFileOptions{
+			outDir:    GinkgoT().TempDir(),
+			activeDir: GinkgoT().TempDir(),
+			name:      "arcadia",
+			base:      language.BritishEnglish,
+			languages: []language.Tag{language.Polish},
+		}
+		entries = []underlierEntry{
+			{
+				MessageID:   "files-found.plural",
+				Description: "Number of files found",
+				One:         "{{.Count}} file found",
+				Other:       "{{.Count}} files found",
+				Fields:      []fieldEntry{{Note: "Count", GoType: "int", PluralCount: true}},
+			},
+		}
+	})
+
+	When("target language has more plural categories than the source", func() {
+		It("🧪 should: fill the missing categories with the other form", func() {
+			Expect(merge(entries, o)).To(Succeed())
+
+			messages, err := readMessageFile(filepath.Join(o.outDir, "translate.pl.json"))
+			Expect(err).To(Succeed())
+			Expect(messages).To(HaveKey("files-found.plural"))
+
+			pending := messages["files-found.plural"]
+			Expect(pending.One).To(Equal("{{.Count}} file found"))
+			Expect(pending.Few).To(Equal("{{.Count}} files found"))
+			Expect(pending.Many).To(Equal("{{.Count}} files found"))
+			Expect(pending.Other).To(Equal("{{.Count}} files found"))
+		})
+
+		It("🧪 should: not export the message once every category is translated", func() {
+			Expect(merge(entries, o)).To(Succeed())
+
+			pending, err := os.ReadFile(filepath.Join(o.outDir, "translate.pl.json"))
+			Expect(err).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(o.activeDir, "arcadia.active.pl.json"), pending, 0o600)).To(Succeed())
+
+			Expect(merge(entries, o)).To(Succeed())
+
+			messages, err := readMessageFile(filepath.Join(o.outDir, "translate.pl.json"))
+			Expect(err).To(Succeed())
+			Expect(messages).To(BeEmpty())
+		})
+	})
+
+	When("name is specified", func() {
+		It("🧪 should: write translate file without the name", func() {
+			Expect(merge(entries, o)).To(Succeed())
+
+			Expect(filepath.Join(o.outDir, "translate.pl.json")).To(BeAnExistingFile())
+			Expect(filepath.Join(o.outDir, "arcadia.translate.pl.json")).NotTo(BeAnExistingFile())
+		})
+	})
+})
@@ -28,6 +28,25 @@
 //
 //	go generate ./...
 //
+// # Extract and merge
+//
+// lingo also replaces goi18n extract and merge, taking the messages directly
+// from the Underliers map:
+//
+//	lingo extract   writes active.<base>.json (ID, description, other and
+//	                plural forms) to the output dir.
+//	lingo merge     writes translate.<lang>.json to the output dir, for each
+//	                language, containing the messages that are not yet
+//	                translated in the existing <name>.active.<lang>.json, or
+//	                whose hash shows the source has since changed.
+//	lingo check     reports the stale IDs, missing IDs, {{.Token}}
+//	                mismatches and hash mismatches of every
+//	                <name>.active.<lang>.json against the Underliers,
+//	                exiting non-zero if any are found.
+//	lingo stale     reports the translations of every
+//	                <name>.active.<lang>.json whose source text has changed
+//	                since they were translated, as denoted by their hash.
+//
 // # Flags
 //
 //	--locale <path>   Path to the locale directory, relative to the repo
@@ -36,6 +55,18 @@
 //	                  whole repo.
 //	--dry-run         Validate the Underliers map and report all errors
 //	                  without writing any files.
+//	--out <path>      extract/merge: output dir, relative to the repo root,
+//	                  defaults to <locale>/out/l10n.
+//	--active <path>   merge/check/stale: dir of the existing translation files,
+//	                  relative to the repo root, defaults to <locale>/deploy.
+//	--name <name>     extract/merge/check/stale: prefix of the message file
+//	                  names.
+//	--base <tag>      extract/merge: language the messages are authored in,
+//	                  defaults to en-GB.
+//	--lang <tags>     merge: comma separated languages, defaulting to those
+//	                  of the existing translation files.
+//	--mark            stale: mark the stale translations in the translation
+//	                  files, by prefixing their hash with "stale-".
 //
 //nolint:all
 package main
@@ -57,6 +88,8 @@ import (
 	"strings"
 	"text/template"
 	"unicode"
+
+	"golang.org/x/text/language"
 )
 
 // ---------------------------------------------------------------------------
@@ -168,7 +201,24 @@ func run() error {
 	dryRun := flag.Bool("dry-run", false, "validate only, do not write files")
 	verbose := flag.Bool("verbose", false, "print per-message diagnostic info during validation")
 	isLib := flag.Bool("lib", false, "generate Render calls (library module) instead of Text calls (application module)")
-	flag.Parse()
+	outFlagVal := flag.String("out", "", "extract/merge: output dir relative to repo root (default <locale>/out/l10n)")
+	activeFlagVal := flag.String("active", "", "merge/check/stale: dir of the existing translation files relative to repo root (default <locale>/deploy)")
+	name := flag.String("name", "", "extract/merge/check/stale: prefix of message file names, eg <name>.active.<lang>.json")
+	base := flag.String("base", "en-GB", "extract/merge: language the messages are authored in")
+	langs := flag.String("lang", "", "merge: comma separated languages (default: those of the existing translation files)")
+	mark := flag.Bool("mark", false, "stale: mark the stale translations in the translation files")
+
+	// the mode, if specified, precedes the flags
+	mode, args := "generate", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		mode, args = args[0], args[1:]
+	}
+	if err := flag.CommandLine.Parse(args); err != nil {
+		return err
+	}
+	if mode != "generate" && mode != "extract" && mode != "merge" && mode != "check" && mode != "stale" {
+		return fmt.Errorf("unknown mode %q: expected extract, merge, check or stale", mode)
+	}
 
 	repoRoot, err := findRepoRoot()
 	if err != nil {
@@ -194,7 +244,44 @@ func run() error {
 		return nil
 	}
 
-	return generate(localeDir, pkgName, baseStruct, underliers, *isLib)
+	if mode == "generate" {
+		return generate(localeDir, pkgName, baseStruct, underliers, *isLib)
+	}
+
+	o := &messageFileOptions{
+		outDir:    filepath.Join(localeDir, "out", "l10n"),
+		activeDir: filepath.Join(localeDir, "deploy"),
+		name:      *name,
+	}
+	if *outFlagVal != "" {
+		o.outDir = filepath.Join(repoRoot, *outFlagVal)
+	}
+	if *activeFlagVal != "" {
+		o.activeDir = filepath.Join(repoRoot, *activeFlagVal)
+	}
+	if o.base, err = language.Parse(*base); err != nil {
+		return fmt.Errorf("--base %q: %w", *base, err)
+	}
+	for _, l := range strings.Split(*langs, ",") {
+		if l = strings.TrimSpace(l); l == "" {
+			continue
+		}
+		tag, err := language.Parse(l)
+		if err != nil {
+			return fmt.Errorf("--lang %q: %w", l, err)
+		}
+		o.languages = append(o.languages, tag)
+	}
+
+	switch mode {
+	case "extract":
+		return extract(underliers, o)
+	case "check":
+		return check(underliers, o)
+	case "stale":
+		return detectStale(underliers, o, *mark)
+	}
+	return merge(underliers, o)
 }
 
 // ---------------------------------------------------------------------------
@@ -377,7 +464,14 @@ type underlierEntry struct {
 	Description string
 	Story       string
 	Other       string
-	Fields      []fieldEntry
+	// Zero, One, Two, Few and Many are the optional CLDR plural forms of
+	// the message. Other remains the mandatory catch-all form.
+	Zero   string
+	One    string
+	Two    string
+	Few    string
+	Many   string
+	Fields []fieldEntry
 	// File is the optional output-file prefix parsed from the Underliers entry.
 	// An empty string means "use the default file for this message kind". When
 	// non-empty, lingo routes the message to a custom output file whose name is
@@ -388,9 +482,50 @@ type underlierEntry struct {
 }
 
 type fieldEntry struct {
-	Note   string
-	GoType string
-	Tale   string
+	Note        string
+	GoType      string
+	Tale        string
+	PluralCount bool
+	// Format is the optional name of the template function the field is
+	// formatted with, eg "number", see li18ngo.TemplateFuncs.
+	Format string
+}
+
+// pluralForm is a single named plural form of a message, eg One.
+type pluralForm struct {
+	Name  string
+	Value string
+}
+
+// plurals returns the plural forms defined on the entry, in CLDR category
+// order. Forms that have not been defined are omitted.
+func (e underlierEntry) plurals() []pluralForm {
+	forms := []pluralForm{
+		{Name: "Zero", Value: e.Zero},
+		{Name: "One", Value: e.One},
+		{Name: "Two", Value: e.Two},
+		{Name: "Few", Value: e.Few},
+		{Name: "Many", Value: e.Many},
+	}
+
+	var defined []pluralForm
+	for _, form := range forms {
+		if form.Value != "" {
+			defined = append(defined, form)
+		}
+	}
+	return defined
+}
+
+// countField returns the name of the field designated as the plural count,
+// or an empty string if there is none.
+func (e underlierEntry) countField() string {
+	for _, f := range e.Fields {
+		if f.PluralCount {
+			return f.Note
+		}
+	}
+	return ""
 }
 
 func parseUnderliers(dir string) ([]underlierEntry, string, string, error) {
@@ -578,6 +713,16 @@ func extractEntry(cl *ast.CompositeLit) (underlierEntry, error) {
 			e.Story = stringLit(kv.Value)
 		case "Other":
 			e.Other = stringLit(kv.Value)
+		case "Zero":
+			e.Zero = stringLit(kv.Value)
+		case "One":
+			e.One = stringLit(kv.Value)
+		case "Two":
+			e.Two = stringLit(kv.Value)
+		case "Few":
+			e.Few = stringLit(kv.Value)
+		case "Many":
+			e.Many = stringLit(kv.Value)
 		case "Fields":
 			fields, err := extractFields(kv.Value)
 			if err != nil {
@@ -619,6 +764,10 @@ func extractFields(node ast.Expr) ([]fieldEntry, error) {
 				f.GoType = stringLit(kv.Value)
 			case "Tale":
 				f.Tale = stringLit(kv.Value)
+			case "PluralCount":
+				f.PluralCount = identOrSel(kv.Value) == "true"
+			case "Format":
+				f.Format = stringLit(kv.Value)
 			}
 		}
 		fields = append(fields, f)
@@ -800,6 +949,9 @@ func validate(entries []underlierEntry, verbose bool) error {
 			}
 		}
 
+		errs = append(errs, validatePlurals(e, fieldNames)...)
+		errs = append(errs, validateFormats(e)...)
+
 		if e.Seed == "" {
 			errs = append(errs, validationError{e.MessageID, "Seed", "Seed must not be empty"})
 		}
@@ -824,7 +976,118 @@ func validate(entries []underlierEntry, verbose bool) error {
 	return errors.New(sb.String())
 }
 
-var templateTokenRe = regexp.MustCompile(`\{\{\.([A-Za-z_][A-Za-z0-9_]*)\}\}`)
+// numericGoTypes are the Go types that go-i18n accepts as a plural count.
+var numericGoTypes = map[string]bool{
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+}
+
+// validatePlurals checks the plural forms and the plural count designation
+// of an entry. Plural forms require exactly one numeric field designated as
+// the PluralCount, and every {{.Token}} in a plural form must have a
+// matching Fields entry.
+func validatePlurals(e underlierEntry, fieldNames map[string]bool) []error {
+	var errs []error
+
+	var counts []fieldEntry
+	for _, f := range e.Fields {
+		if f.Note == "PluralCount" {
+			errs = append(errs, validationError{e.MessageID, f.Note,
+				"a field may not be named \"PluralCount\", it clashes with the generated method"})
+		}
+		if f.PluralCount {
+			counts = append(counts, f)
+		}
+	}
+
+	if len(counts) > 1 {
+		errs = append(errs, validationError{e.MessageID, "Fields",
+			"at most one field may be designated as the PluralCount"})
+	}
+	for _, f := range counts {
+		if !numericGoTypes[f.GoType] {
+			errs = append(errs, validationError{e.MessageID, f.Note,
+				fmt.Sprintf("the PluralCount field must have a numeric GoType, not %q", f.GoType)})
+		}
+	}
+
+	plurals := e.plurals()
+	if len(plurals) > 0 && len(counts) == 0 {
+		errs = append(errs, validationError{e.MessageID, "Fields",
+			"plural forms require a Fields entry designated as the PluralCount"})
+	}
+	if len(plurals) == 0 && len(counts) > 0 {
+		errs = append(errs, validationError{e.MessageID, counts[0].Note,
+			"PluralCount designated but no plural forms (Zero/One/Two/Few/Many) defined"})
+	}
+
+	for _, form := range plurals {
+		for _, tok := range extractTemplateTokens(form.Value) {
+			if !fieldNames[tok] {
+				errs = append(errs, validationError{e.MessageID, tok,
+					fmt.Sprintf("{{.%s}} in %s has no matching Fields entry", tok, form.Name)})
+			}
+		}
+	}
+
+	return errs
+}
+
+// formatGoTypes maps each Format to the GoTypes it may be applied to; a nil
+// entry denotes the numeric types.
+var formatGoTypes = map[string]map[string]bool{
+	"number":   nil,
+	"percent":  nil,
+	"bytes":    nil,
+	"date":     {"time.Time": true},
+	"duration": {"time.Duration": true},
+}
+
+// validateFormats checks that the Format of each field is known and is
+// applicable to the field's GoType.
+func validateFormats(e underlierEntry) []error {
+	var errs []error
+
+	for _, f := range e.Fields {
+		if f.Format == "" {
+			continue
+		}
+
+		goTypes, known := formatGoTypes[f.Format]
+		if !known {
+			errs = append(errs, validationError{e.MessageID, f.Note,
+				fmt.Sprintf("unknown Format %q, expected number, percent, bytes, date or duration", f.Format)})
+			continue
+		}
+
+		if goTypes == nil {
+			goTypes = numericGoTypes
+		}
+		if !goTypes[f.GoType] {
+			errs = append(errs, validationError{e.MessageID, f.Note,
+				fmt.Sprintf("Format %q is not applicable to GoType %q", f.Format, f.GoType)})
+		}
+	}
+
+	return errs
+}
+
+// templateTokenRe matches a {{.Token}}, including one emitted with a Format,
+// eg {{number .Token}}.
+var templateTokenRe = regexp.MustCompile(`\{\{(?:[a-z]+ )?\.([A-Za-z_][A-Za-z0-9_]*)\}\}`)
+
+// formatted returns the text with the {{.Token}} of each field that declares
+// a Format emitted as {{<Format> .Token}}, so that it is formatted by the
+// template function of that name.
+func (e underlierEntry) formatted(text string) string {
+	for _, f := range e.Fields {
+		if f.Format != "" {
+			text = strings.ReplaceAll(text, "{{."+f.Note+"}}", "{{"+f.Format+" ."+f.Note+"}}")
+		}
+	}
+	return text
+}
 
 func extractTemplateTokens(s string) []string {
 	matches := templateTokenRe.FindAllStringSubmatch(s, -1)
@@ -960,6 +1223,14 @@ type templateData struct {
 	Base        string
 	Fields      []fieldEntry // non-error fields only (GoType != "error")
 
+	// Plurals are the plural forms defined in addition to Other, each with
+	// its value already rendered as a Go string literal.
+	Plurals []pluralForm
+
+	// CountField is the name of the field designated as the plural count.
+	// When non-empty, a PluralCount method is generated for the struct.
+	CountField string
+
 	StructName  string
 	ErrorTD     string
 	ErrorStruct string
@@ -989,6 +1260,11 @@ func newTemplateData(e underlierEntry, base string, useRender bool) templateData
 	// it must not appear in the field loop or the constructor parameter list.
 	nef := nonErrorFields(e.Fields)
 
+	plurals := e.plurals()
+	for i := range plurals {
+		plurals[i].Value = goStringLit(e.formatted(plurals[i].Value))
+	}
+
 	structName := e.Seed + "TemplData"
 	errorTD := e.Seed + "ErrorTemplData"
 	errorStruct := e.Seed + "Error"
@@ -997,9 +1273,11 @@ func newTemplateData(e underlierEntry, base string, useRender bool) templateData
 		Seed:           e.Seed,
 		MessageID:      e.MessageID,
 		Description:    e.Description,
-		Other:          goStringLit(e.Other),
+		Other:          goStringLit(e.formatted(e.Other)),
 		Base:           base,
 		Fields:         nef,
+		Plurals:        plurals,
+		CountField:     e.countField(),
 		StructName:     structName,
 		ErrorTD:        errorTD,
 		ErrorStruct:    errorStruct,
@@ -1073,8 +1351,18 @@ func (td {{.StructName}}) Message() *i18n.Message {
 		ID:          {{printf "%q" .MessageID}},
 		Description: {{printf "%q" .Description}},
 		Other:       {{.Other}},
+{{- range .Plurals}}
+		{{.Name}}: {{.Value}},
+{{- end}}
 	}
 }
+{{- if .CountField}}
+
+{{wrap (printf "PluralCount returns the count that selects the plural form of the message for %s." .StructName) "// " 80}}
+func (td {{.StructName}}) PluralCount() any {
+	return td.{{.CountField}}
+}
+{{- end}}
 {{if .Fields}}
 {{wrap (printf "New%s creates a new %s." .StructName .StructName) "// " 80}}
 func New{{.StructName}}({{.Params}}) {{.StructName}} {
@@ -1108,8 +1396,18 @@ func (td {{.StructName}}) Message() *i18n.Message {
 		ID:          {{printf "%q" .MessageID}},
 		Description: {{printf "%q" .Description}},
 		Other:       {{.Other}},
+{{- range .Plurals}}
+		{{.Name}}: {{.Value}},
+{{- end}}
 	}
 }
+{{- if .CountField}}
+
+{{wrap (printf "PluralCount returns the count that selects the plural form of the message for %s." .StructName) "// " 80}}
+func (td {{.StructName}}) PluralCount() any {
+	return td.{{.CountField}}
+}
+{{- end}}
 {{if .Fields}}
 {{wrap (printf "New%s creates a new %s." .StructName .StructName) "// " 80}}
 func New{{.StructName}}({{.Params}}) {{.StructName}} {
@@ -1136,6 +1434,9 @@ func (td {{.ErrorTD}}) Message() *i18n.Message {
 		ID:          {{printf "%q" .MessageID}},
 		Description: {{printf "%q" .Description}},
 		Other:       {{.Other}},
+{{- range .Plurals}}
+		{{.Name}}: {{.Value}},
+{{- end}}
 	}
 }
 
@@ -1168,6 +1469,9 @@ func (td {{.ErrorTD}}) Message() *i18n.Message {
 		ID:          {{printf "%q" .MessageID}},
 		Description: {{printf "%q" .Description}},
 		Other:       {{.Other}},
+{{- range .Plurals}}
+		{{.Name}}: {{.Value}},
+{{- end}}
 	}
 }
 
@@ -1204,6 +1508,9 @@ func (td {{.ErrorTD}}) Message() *i18n.Message {
 		ID:          {{printf "%q" .MessageID}},
 		Description: {{printf "%q" .Description}},
 		Other:       {{.Other}},
+{{- range .Plurals}}
+		{{.Name}}: {{.Value}},
+{{- end}}
 	}
 }
 
@@ -1254,6 +1561,9 @@ func (td {{.ErrorTD}}) Message() *i18n.Message {
 		ID:          {{printf "%q" .MessageID}},
 		Description: {{printf "%q" .Description}},
 		Other:       {{.Other}},
+{{- range .Plurals}}
+		{{.Name}}: {{.Value}},
+{{- end}}
 	}
 }
 
@@ -1309,8 +1619,18 @@ func (td {{.StructName}}) Message() *i18n.Message {
 		ID:          {{printf "%q" .MessageID}},
 		Description: {{printf "%q" .Description}},
 		Other:       {{.Other}},
+{{- range .Plurals}}
+		{{.Name}}: {{.Value}},
+{{- end}}
 	}
 }
+{{- if .CountField}}
+
+{{wrap (printf "PluralCount returns the count that selects the plural form of the message for %s." .StructName) "// " 80}}
+func (td {{.StructName}}) PluralCount() any {
+	return td.{{.CountField}}
+}
+{{- end}}
 
 {{.ErrorComment}}
 type {{.ErrorStruct}} struct {
@@ -1366,8 +1686,18 @@ func (td {{.StructName}}) Message() *i18n.Message {
 		ID:          {{printf "%q" .MessageID}},
 		Description: {{printf "%q" .Description}},
 		Other:       {{.Other}},
+{{- range .Plurals}}
+		{{.Name}}: {{.Value}},
+{{- end}}
 	}
 }
+{{- if .CountField}}
+
+{{wrap (printf "PluralCount returns the count that selects the plural form of the message for %s." .StructName) "// " 80}}
+func (td {{.StructName}}) PluralCount() any {
+	return td.{{.CountField}}
+}
+{{- end}}
 
 {{.ErrorComment}}
 type {{.ErrorStruct}} struct {
@@ -1492,9 +1822,9 @@ func banner(e underlierEntry) string {
 
 func generateCobra(pkg, base string, entries []underlierEntry, useRender bool) ([]byte, error) {
 	var sb strings.Builder
-	sb.WriteString(renderHeader(pkg, []string{
+	sb.WriteString(renderHeader(pkg, append(fieldImports(entries),
 		"github.com/nicksnyder/go-i18n/v2/i18n",
-	}))
+	)))
 	for _, e := range entries {
 		sb.WriteString("\n")
 		sb.WriteString(banner(e))
@@ -1514,9 +1844,9 @@ func generateCobra(pkg, base string, entries []underlierEntry, useRender bool) (
 
 func generateGeneral(pkg, base string, entries []underlierEntry, useRender bool) ([]byte, error) {
 	var sb strings.Builder
-	sb.WriteString(renderHeader(pkg, []string{
+	sb.WriteString(renderHeader(pkg, append(fieldImports(entries),
 		"github.com/nicksnyder/go-i18n/v2/i18n",
-	}))
+	)))
 	for _, e := range entries {
 		sb.WriteString("\n")
 		sb.WriteString(banner(e))
@@ -1544,10 +1874,10 @@ func generateErrors(pkg, base string, entries []underlierEntry, useRender bool)
 			needFmt = true
 		}
 	}
-	imports := []string{
+	imports := append(fieldImports(entries),
 		"github.com/nicksnyder/go-i18n/v2/i18n",
 		"github.com/snivilised/li18ngo",
-	}
+	)
 	if needFmt {
 		imports = append([]string{"fmt"}, imports...)
 	}
@@ -1591,6 +1921,19 @@ func renderErrorEntry(e underlierEntry, base string, useRender bool) (string, er
 // Helpers
 // ---------------------------------------------------------------------------
 
+// fieldImports returns the standard library imports required by the GoType
+// of the fields of the entries, ie "time" for time.Time and time.Duration.
+func fieldImports(entries []underlierEntry) []string {
+	for _, e := range entries {
+		for _, f := range nonErrorFields(e.Fields) {
+			if strings.HasPrefix(f.GoType, "time.") {
+				return []string{"time"}
+			}
+		}
+	}
+	return nil
+}
+
 // nonErrorFields returns all fields whose GoType is not "error". The
 // error-typed Wrapped field is handled implicitly by wrapper templates
 // as an unexported wrapped field on the error struct, so it must not
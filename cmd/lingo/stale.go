@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/snivilised/li18ngo/internal/third/lo"
+	"github.com/snivilised/li18ngo/tools/sorter"
+	"github.com/snivilised/li18ngo/tools/stale"
+)
+
+// ---------------------------------------------------------------------------
+// Stale
+//
+// The stale mode reports the translations whose source text has changed
+// since they were translated, as denoted by the hash recorded in each
+// translation file, and optionally marks them stale in the file.
+// ---------------------------------------------------------------------------
+
+// detectStale reports the stale translations of every translation file in
+// the active directory, marking them in the file if requested.
+func detectStale(entries []underlierEntry, o *messageFileOptions, mark bool) error {
+	files, err := activeFiles(o)
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		return fmt.Errorf("no translation files found in %q", o.activeDir)
+	}
+
+	sources := make(map[string]sorter.MessageEntry, len(entries))
+	for _, e := range entries {
+		sources[e.MessageID] = sourceMessage(e)
+	}
+
+	for _, file := range files {
+		path := filepath.Join(o.activeDir, file)
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading translation file: %w", err)
+		}
+
+		translations, err := stale.Detect(sources, data)
+		if err != nil {
+			return fmt.Errorf("parsing translation file %q: %w", path, err)
+		}
+
+		if len(translations) == 0 {
+			fmt.Printf("lingo: %s - no stale translations\n", file)
+			continue
+		}
+
+		fmt.Printf("lingo: %s - %d stale translation(s):\n", file, len(translations))
+		for _, t := range translations {
+			fmt.Printf("  - message %q: translated from %s, source is now %s%s\n",
+				t.ID, t.Recorded, t.Current, lo.Ternary(t.Marked, " (marked)", ""),
+			)
+		}
+
+		if !mark {
+			continue
+		}
+
+		marked, err := stale.Mark(data, translations)
+		if err != nil {
+			return fmt.Errorf("marking translation file %q: %w", path, err)
+		}
+		if err := os.WriteFile(path, marked, 0o644); err != nil { //nolint:gosec // translation files are not sensitive
+			return fmt.Errorf("writing %q: %w", path, err)
+		}
+		fmt.Printf("lingo: marked %s\n", path)
+	}
+
+	return nil
+}
@@ -0,0 +1,3 @@
+// Package main provides the xliff-i18n command-line tool for exporting li18ngo
+// messages awaiting translation to XLIFF 2.0 and importing the translations.
+package main
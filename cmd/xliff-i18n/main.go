@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/snivilised/li18ngo/tools/mio"
+	"github.com/snivilised/li18ngo/tools/xliff"
+)
+
+var extensions = []string{".xlf", ".xliff"}
+
+// transfer exports or imports, in the direction implied by the extensions of
+// the input and output addresses.
+func transfer(input, output mio.Transport, options xliff.Options) error {
+	from, to := filepath.Ext(input.Address()), filepath.Ext(output.Address())
+
+	switch {
+	case from == ".json" && slices.Contains(extensions, to):
+		return xliff.Export(input, output, options)
+	case slices.Contains(extensions, from) && to == ".json":
+		return xliff.Import(input, output)
+	}
+
+	return fmt.Errorf("unsupported conversion: '%v' to '%v'", from, to)
+}
+
+func main() {
+	path := flag.String("path", "",
+		"Path to the input file; the translate JSON file to export, or the XLIFF file to import",
+	)
+	out := flag.String("out", "",
+		"Path to the output file; the XLIFF file to export to, or the active JSON file to import into",
+	)
+	src := flag.String("src", "en-GB",
+		"Source language, ie the base language (export only)",
+	)
+	trg := flag.String("trg", "",
+		"Target language (export only)",
+	)
+	active := flag.String("active", "",
+		"Path to the active JSON file of the target language, whose translated messages are not exported (export only)",
+	)
+
+	flag.Parse()
+
+	if *path == "" || *out == "" {
+		fmt.Println("Usage: xliff-i18n -path <path-to-input-file> -out <path-to-output-file> [-src <tag>] [-trg <tag>] [-active <path-to-active-file>]")
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	options := xliff.Options{
+		SourceLanguage: *src,
+		TargetLanguage: *trg,
+	}
+
+	if *active != "" {
+		data, err := (&mio.NativeReaderWriterFS{Path: *active}).Read()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		options.Active = data
+	}
+
+	if err := transfer(
+		&mio.NativeReaderWriterFS{Path: *path},
+		&mio.NativeReaderWriterFS{Path: *out},
+		options,
+	); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,106 @@
+package translate_test
+
+import (
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/lab"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+const deutschSourceID = "github.com/snivilised/deutsch"
+
+// tramTemplData is a message authored in German
+type tramTemplData struct{}
+
+func (td tramTemplData) SourceID() string {
+	return deutschSourceID
+}
+
+func (td tramTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "tram.deutsch.test",
+		Description: "Tram",
+		Other:       "Straßenbahn",
+	}
+}
+
+var _ = Describe("Base", func() {
+	var l10nPath string
+
+	BeforeEach(func() {
+		translate.ResetTx()
+		l10nPath = lab.Repo("test/data/l10n")
+	})
+
+	Context("source authored in a language other than the default", func() {
+		When("the requested language is the default", func() {
+			It("🧪 should: translate from the source's base language", func() {
+				Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+					o.Tag = language.BritishEnglish
+					o.DefaultIsAcceptable = false
+					o.From = li18ngo.LoadFrom{
+						Path: l10nPath,
+						Sources: li18ngo.TranslationFiles{
+							deutschSourceID: li18ngo.TranslationSource{
+								Name: "test.deutsch",
+								Base: language.German,
+							},
+						},
+					}
+				})).To(Succeed())
+
+				Expect(li18ngo.Text(tramTemplData{})).To(Equal("tram"))
+			})
+		})
+
+		When("the requested language is the source's base language", func() {
+			It("🧪 should: present the authored message", func() {
+				Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+					o.Tag = language.German
+					o.From = li18ngo.LoadFrom{
+						Path: l10nPath,
+						Sources: li18ngo.TranslationFiles{
+							deutschSourceID: li18ngo.TranslationSource{
+								Name: "test.deutsch",
+								Base: language.German,
+							},
+						},
+					}
+				})).To(Succeed())
+
+				Expect(li18ngo.Text(tramTemplData{})).To(Equal("Straßenbahn"))
+			})
+		})
+	})
+
+	Context("UseOptions.Base", func() {
+		When("Tag is not specified", func() {
+			It("🧪 should: use the base language", func() {
+				tx, err := li18ngo.NewTranslator(func(o *li18ngo.UseOptions) {
+					o.Base = language.AmericanEnglish
+				})
+				Expect(err).To(Succeed())
+
+				Expect(tx.LanguageInfo().Tag).To(Equal(language.AmericanEnglish))
+				Expect(tx.LanguageInfo().Default).To(Equal(language.AmericanEnglish))
+				Expect(tx.Localise(locale.LocalisationTemplData{})).To(Equal("localisation"))
+			})
+		})
+
+		When("requested language is not supported", func() {
+			It("🧪 should: fall back to the base language", func() {
+				tx, err := li18ngo.NewTranslator(func(o *li18ngo.UseOptions) {
+					o.Base = language.AmericanEnglish
+					o.Tag = language.Japanese
+				})
+				Expect(err).To(Succeed())
+				Expect(tx.LanguageInfo().Tag).To(Equal(language.AmericanEnglish))
+			})
+		})
+	})
+})
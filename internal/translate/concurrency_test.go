@@ -0,0 +1,113 @@
+package translate_test
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+var _ = Describe("Concurrency", func() {
+	BeforeEach(func() {
+		translate.ResetTx()
+	})
+
+	Context("Text", func() {
+		When("invoked from many goroutines while Register is called", func() {
+			It("🧪 should: localise consistently without racing", func() {
+				// Run with -race to detect unguarded access to the active
+				// translator or the localizer container.
+				const (
+					readers    = 16
+					iterations = 50
+				)
+
+				Expect(li18ngo.Use()).To(Succeed())
+
+				var wg sync.WaitGroup
+
+				wg.Add(readers + 1)
+
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					for range iterations {
+						Expect(li18ngo.Register()).To(Succeed())
+					}
+				}()
+
+				for range readers {
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+
+						for range iterations {
+							Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(
+								Equal("localisation"),
+							)
+							// foreign source, forces a localizer to be created
+							// on the fly
+							Expect(li18ngo.Text(ParadiseLostTemplData{})).To(
+								Equal("paradise lost"),
+							)
+						}
+					}()
+				}
+
+				wg.Wait()
+			})
+		})
+	})
+
+	Context("Use", func() {
+		When("invoked for the first time while Register is called", func() {
+			It("🧪 should: retain the library's registration", func() {
+				applying := make(chan struct{})
+				registered := make(chan struct{})
+
+				var wg sync.WaitGroup
+
+				wg.Add(1)
+
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					<-applying
+					Expect(li18ngo.Register(registerLibrary)).To(Succeed())
+					close(registered)
+				}()
+
+				// Register is invoked whilst Use is resolving its language,
+				// after the sources registered have been included, so must not
+				// complete until Use has activated its translator; the timeout
+				// prevents a deadlock.
+				Expect(li18ngo.Use(useAmericanEnglish, func(o *li18ngo.UseOptions) {
+					o.Detector = func(li18ngo.SupportedLanguages) (language.Tag, language.Confidence) {
+						close(applying)
+
+						select {
+						case <-registered:
+						case <-time.After(time.Millisecond * 50):
+						}
+
+						return language.Und, language.No
+					}
+				})).To(Succeed())
+
+				wg.Wait()
+
+				Expect(li18ngo.Text(colourTemplData{})).To(Equal("color"),
+					"registration should not be lost to the translator replaced by Use",
+				)
+			})
+		})
+	})
+})
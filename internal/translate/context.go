@@ -0,0 +1,72 @@
+package translate
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+)
+
+type contextKey int
+
+const (
+	languageKey contextKey = iota
+	translatorKey
+)
+
+// WithLanguage returns a copy of ctx that requests messages be localised
+// into the language tag specified, when used with TextCtx or RenderCtx.
+func WithLanguage(ctx context.Context, tag language.Tag) context.Context {
+	return context.WithValue(ctx, languageKey, tag)
+}
+
+// WithTranslator returns a copy of ctx that carries the translator
+// specified, typically one created with NewTranslator. A translator in the
+// context takes precedence over a language set with WithLanguage.
+func WithTranslator(ctx context.Context, tx Translator) context.Context {
+	return context.WithValue(ctx, translatorKey, tx)
+}
+
+// TextCtx is the context aware version of Text. The translator is resolved
+// from ctx; either the one set by WithTranslator, or one derived from the
+// active translator for the language set by WithLanguage. If ctx contains
+// neither, TextCtx behaves exactly like Text.
+func TextCtx(ctx context.Context, data Localisable) string {
+	if tx := fromContext(ctx); tx != nil {
+		return tx.Localise(data)
+	}
+
+	return Text(data)
+}
+
+// RenderCtx is the context aware version of Render. The translator is
+// resolved in the same way as TextCtx, but like Render, it is safe to
+// call before Use has been invoked.
+func RenderCtx(ctx context.Context, data Localisable) string {
+	if tx := fromContext(ctx); tx != nil {
+		return tx.Localise(data)
+	}
+
+	return Render(data)
+}
+
+func fromContext(ctx context.Context) Translator {
+	if tx, ok := ctx.Value(translatorKey).(Translator); ok && tx != nil {
+		return tx
+	}
+
+	tag, ok := ctx.Value(languageKey).(language.Tag)
+	if !ok {
+		return nil
+	}
+
+	tx := current()
+	if tx == nil {
+		return nil
+	}
+
+	if derived, err := tx.forLanguage(tag); err == nil {
+		return derived
+	}
+
+	return tx
+}
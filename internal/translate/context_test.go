@@ -0,0 +1,104 @@
+package translate_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/lab"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+var _ = Describe("Context", func() {
+	var (
+		ctx  context.Context
+		use  li18ngo.UseOptionFn
+		from li18ngo.LoadFrom
+	)
+
+	BeforeEach(func() {
+		translate.ResetTx()
+		ctx = context.Background()
+		from = li18ngo.LoadFrom{
+			Path: lab.Repo("test/data/l10n"),
+			Sources: li18ngo.TranslationFiles{
+				li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+			},
+		}
+		use = func(o *li18ngo.UseOptions) {
+			o.Tag = language.BritishEnglish
+			o.From = from
+		}
+	})
+
+	Context("TextCtx", func() {
+		When("context contains a language", func() {
+			It("🧪 should: localise in the language of the context", func() {
+				Expect(li18ngo.Use(use)).To(Succeed())
+
+				us := li18ngo.WithLanguage(ctx, language.AmericanEnglish)
+				Expect(li18ngo.TextCtx(us, locale.LocalisationTemplData{})).To(
+					Equal("localization"),
+				)
+				Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(
+					Equal("localisation"), "global translator should be unaffected",
+				)
+			})
+		})
+
+		When("context contains an unsupported language", func() {
+			It("🧪 should: fall back to the global translator", func() {
+				Expect(li18ngo.Use(use)).To(Succeed())
+
+				jp := li18ngo.WithLanguage(ctx, language.Japanese)
+				Expect(li18ngo.TextCtx(jp, locale.LocalisationTemplData{})).To(
+					Equal("localisation"),
+				)
+			})
+		})
+
+		When("context contains a translator", func() {
+			It("🧪 should: localise with the translator of the context", func() {
+				Expect(li18ngo.Use(use)).To(Succeed())
+
+				tx, err := li18ngo.NewTranslator(func(o *li18ngo.UseOptions) {
+					o.Tag = language.AmericanEnglish
+					o.From = from
+				})
+				Expect(err).To(Succeed())
+
+				withTx := li18ngo.WithTranslator(
+					li18ngo.WithLanguage(ctx, language.BritishEnglish), tx,
+				)
+				Expect(li18ngo.TextCtx(withTx, locale.LocalisationTemplData{})).To(
+					Equal("localization"),
+				)
+			})
+		})
+
+		When("context is empty", func() {
+			It("🧪 should: use the global translator", func() {
+				Expect(li18ngo.Use(use)).To(Succeed())
+
+				Expect(li18ngo.TextCtx(ctx, locale.LocalisationTemplData{})).To(
+					Equal("localisation"),
+				)
+			})
+		})
+	})
+
+	Context("RenderCtx", func() {
+		When("Use has not been called", func() {
+			It("🧪 should: return the canonical Other string", func() {
+				us := li18ngo.WithLanguage(ctx, language.AmericanEnglish)
+				Expect(li18ngo.RenderCtx(us, localisableErrorFixtureTemplData{})).To(
+					Equal("something went wrong in the fixture"),
+				)
+			})
+		})
+	})
+})
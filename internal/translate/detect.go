@@ -0,0 +1,81 @@
+package translate
+
+import (
+	"os"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// localeEnvVars are the environment variables consulted by DetectEnv, in
+// order of precedence, as defined by POSIX for message catalogues.
+var localeEnvVars = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+// Detect matches the preferred languages against the supported languages and
+// returns the best supported tag, along with the confidence of the match. If
+// there is no match, the confidence will be language.No.
+func Detect(supported SupportedLanguages, preferred ...language.Tag) (language.Tag, language.Confidence) {
+	if len(supported) == 0 || len(preferred) == 0 {
+		return language.Und, language.No
+	}
+
+	matcher := language.NewMatcher(supported)
+	_, index, confidence := matcher.Match(preferred...)
+
+	// the tag returned by Match may be decorated with extensions (eg -u-rg-),
+	// so the supported tag is returned instead.
+	return supported[index], confidence
+}
+
+// DetectEnv detects the language from the environment, consulting LC_ALL,
+// LC_MESSAGES and LANG in that order. Values in the POSIX locale form, eg
+// 'en_GB.UTF-8', are accepted; the C and POSIX locales are ignored.
+func DetectEnv(supported SupportedLanguages) (language.Tag, language.Confidence) {
+	for _, name := range localeEnvVars {
+		if tag, ok := parseLocale(os.Getenv(name)); ok {
+			return Detect(supported, tag)
+		}
+	}
+
+	return language.Und, language.No
+}
+
+// DetectAcceptLanguage detects the language from the value of a HTTP
+// Accept-Language header, eg 'fr-CH, fr;q=0.9, en;q=0.8'.
+func DetectAcceptLanguage(header string, supported SupportedLanguages) (language.Tag, language.Confidence) {
+	preferred, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return language.Und, language.No
+	}
+
+	return Detect(supported, preferred...)
+}
+
+// WithDetectedLanguage is a UseOptionFn that sets the language from the
+// environment (see DetectEnv). Detection is performed against the supported
+// languages after all other options have been applied, so the position of
+// this option in the list passed to Use is not significant. When nothing
+// can be detected, the Tag is left unchanged.
+func WithDetectedLanguage() UseOptionFn {
+	return func(o *UseOptions) {
+		o.Detector = DetectEnv
+	}
+}
+
+// parseLocale converts a POSIX locale, eg 'en_GB.UTF-8@euro', into a tag.
+func parseLocale(value string) (language.Tag, bool) {
+	if i := strings.IndexAny(value, ".@"); i >= 0 {
+		value = value[:i]
+	}
+
+	if value == "" || value == "C" || value == "POSIX" {
+		return language.Und, false
+	}
+
+	tag, err := language.Parse(strings.ReplaceAll(value, "_", "-"))
+	if err != nil {
+		return language.Und, false
+	}
+
+	return tag, true
+}
@@ -0,0 +1,94 @@
+package translate_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+var _ = Describe("Detect", func() {
+	var supported li18ngo.SupportedLanguages
+
+	BeforeEach(func() {
+		translate.ResetTx()
+		supported = li18ngo.SupportedLanguages{
+			language.BritishEnglish,
+			language.AmericanEnglish,
+			language.French,
+		}
+	})
+
+	DescribeTable("DetectAcceptLanguage",
+		func(header string, expected language.Tag, matched bool) {
+			tag, confidence := li18ngo.DetectAcceptLanguage(header, supported)
+
+			Expect(confidence != language.No).To(Equal(matched))
+			if matched {
+				Expect(tag).To(Equal(expected))
+			}
+		},
+		func(header string, expected language.Tag, _ bool) string {
+			return "🧪 should: detect '" + expected.String() + "' from '" + header + "'"
+		},
+		Entry(nil, "fr-CH, fr;q=0.9, en;q=0.8", language.French, true),
+		Entry(nil, "en-US,en;q=0.5", language.AmericanEnglish, true),
+		Entry(nil, "ja", language.Und, false),
+		Entry(nil, "", language.Und, false),
+	)
+
+	Context("DetectEnv", func() {
+		BeforeEach(func() {
+			GinkgoT().Setenv("LC_ALL", "")
+			GinkgoT().Setenv("LC_MESSAGES", "")
+			GinkgoT().Setenv("LANG", "")
+		})
+
+		When("LANG is in POSIX form", func() {
+			It("🧪 should: detect language", func() {
+				GinkgoT().Setenv("LANG", "en_US.UTF-8")
+
+				tag, confidence := li18ngo.DetectEnv(supported)
+				Expect(confidence).To(Equal(language.Exact))
+				Expect(tag).To(Equal(language.AmericanEnglish))
+			})
+		})
+
+		When("LC_ALL and LANG are both set", func() {
+			It("🧪 should: give precedence to LC_ALL", func() {
+				GinkgoT().Setenv("LC_ALL", "fr_FR.UTF-8@euro")
+				GinkgoT().Setenv("LANG", "en_US.UTF-8")
+
+				tag, confidence := li18ngo.DetectEnv(supported)
+				Expect(confidence).NotTo(Equal(language.No))
+				Expect(tag).To(Equal(language.French))
+			})
+		})
+
+		When("the C locale is set", func() {
+			It("🧪 should: not detect a language", func() {
+				GinkgoT().Setenv("LANG", "C")
+
+				_, confidence := li18ngo.DetectEnv(supported)
+				Expect(confidence).To(Equal(language.No))
+			})
+		})
+	})
+
+	Context("WithDetectedLanguage", func() {
+		It("🧪 should: use the language detected from the environment", func() {
+			GinkgoT().Setenv("LC_ALL", "")
+			GinkgoT().Setenv("LC_MESSAGES", "en_US")
+
+			Expect(li18ngo.Use(li18ngo.WithDetectedLanguage())).To(Succeed())
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).NotTo(BeEmpty())
+
+			tx, err := li18ngo.NewTranslator(li18ngo.WithDetectedLanguage())
+			Expect(err).To(Succeed())
+			Expect(tx.LanguageInfo().Tag).To(Equal(language.AmericanEnglish))
+		})
+	})
+})
@@ -0,0 +1,110 @@
+package translate
+
+import (
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/snivilised/li18ngo/internal/third/lo"
+	"golang.org/x/text/language"
+)
+
+// Available returns the languages discovered for each source by the active
+// translator. The result is nil if Use has not been invoked or discovery
+// was not requested (see UseOptions.Discover).
+func Available() Availability {
+	if tx := current(); tx != nil {
+		return tx.LanguageInfo().Discovered
+	}
+
+	return nil
+}
+
+// Languages returns the union of languages discovered across all sources,
+// ordered by tag.
+func (a Availability) Languages() SupportedLanguages {
+	var all SupportedLanguages
+
+	for _, id := range slices.Sorted(maps.Keys(a)) {
+		for _, tag := range a[id] {
+			if !containsLanguage(all, tag) {
+				all = append(all, tag)
+			}
+		}
+	}
+
+	slices.SortFunc(all, compareTags)
+
+	return all
+}
+
+// discover scans the directory of each source for translation files and
+// records the languages found. Sources that do not declare their supported
+// languages, adopt the languages discovered for them.
+func discover(lang *LanguageInfo, fS fs.FS) Availability {
+	discovered := make(Availability)
+
+	for id, source := range lang.From.Sources {
+		accepted := lo.TernaryF(source.format(lang) == "",
+			func() []Format {
+				return formats
+			},
+			func() []Format {
+				return []Format{source.format(lang)}
+			},
+		)
+		tags := scan(resolveDirectory(lang, source, fS), activePrefix(source), accepted,
+			lo.Ternary[fs.FS](source.FS != nil, source.FS, fS),
+		)
+		if len(tags) == 0 {
+			continue
+		}
+
+		discovered[id] = tags
+
+		if len(source.Supported) == 0 {
+			source.Supported = tags
+			lang.From.Sources[id] = source
+		}
+	}
+
+	return discovered
+}
+
+// scan returns the tags of the files in directory that match the pattern
+// <prefix><tag>.<format>, for any of the accepted formats, ordered by tag.
+func scan(directory, prefix string, accepted []Format, fS fs.FS) SupportedLanguages {
+	entries, err := fs.ReadDir(fS, directory)
+	if err != nil {
+		return nil
+	}
+
+	var tags SupportedLanguages
+
+	for _, entry := range entries {
+		name := entry.Name()
+		extension := strings.TrimPrefix(path.Ext(name), ".")
+
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) ||
+			!slices.Contains(accepted, Format(extension)) {
+			continue
+		}
+
+		raw := strings.TrimSuffix(strings.TrimPrefix(name, prefix), "."+extension)
+
+		// names that do not end in a valid tag are ignored
+		if tag, err := language.Parse(raw); err == nil && !containsLanguage(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	slices.SortFunc(tags, compareTags)
+
+	return tags
+}
+
+func compareTags(a, b language.Tag) int {
+	return strings.Compare(a.String(), b.String())
+}
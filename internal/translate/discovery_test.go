@@ -0,0 +1,71 @@
+package translate_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/lab"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+var _ = Describe("Discovery", func() {
+	var from li18ngo.LoadFrom
+
+	BeforeEach(func() {
+		translate.ResetTx()
+		from = li18ngo.LoadFrom{
+			Path: lab.Repo("test/data/l10n"),
+			Sources: li18ngo.TranslationFiles{
+				li18ngo.Li18ngoSourceID:     li18ngo.TranslationSource{Name: "test"},
+				locale.TestGrafficoSourceID: li18ngo.TranslationSource{Name: "test.graffico"},
+			},
+		}
+	})
+
+	Context("Discover", func() {
+		When("enabled", func() {
+			It("🧪 should: populate supported languages from translation files", func() {
+				Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+					o.Tag = language.French
+					o.From = from
+					o.Discover = true
+					o.DefaultIsAcceptable = false
+				})).To(Succeed())
+
+				Expect(li18ngo.Text(locale.NewUsingConfigFileTemplData("jaywalk.yml"))).To(
+					Equal(expectFR),
+				)
+
+				available := li18ngo.Available()
+				Expect(available).To(HaveKeyWithValue(li18ngo.Li18ngoSourceID,
+					li18ngo.SupportedLanguages{
+						language.AmericanEnglish, language.French,
+						language.Portuguese, language.BrazilianPortuguese,
+					},
+				))
+				Expect(available).To(HaveKeyWithValue(locale.TestGrafficoSourceID,
+					li18ngo.SupportedLanguages{language.AmericanEnglish},
+				))
+				Expect(available.Languages()).To(Equal(
+					li18ngo.SupportedLanguages{
+						language.AmericanEnglish, language.French,
+						language.Portuguese, language.BrazilianPortuguese,
+					},
+				))
+			})
+		})
+
+		When("not enabled", func() {
+			It("🧪 should: not discover languages", func() {
+				Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+					o.From = from
+				})).To(Succeed())
+
+				Expect(li18ngo.Available()).To(BeNil())
+			})
+		})
+	})
+})
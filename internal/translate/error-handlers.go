@@ -0,0 +1,34 @@
+package translate
+
+import (
+	"golang.org/x/text/language"
+)
+
+// OtherOnError is an ErrorHandlerFn that presents the untranslated Other
+// form of the message, so that the user is presented with the message in
+// the base language rather than nothing at all. Note that any template
+// tokens in Other are not substituted.
+func OtherOnError(data Localisable, _ string, _ language.Tag, _ error) string {
+	return data.Message().Other
+}
+
+// MessageIDOnError is an ErrorHandlerFn that presents the id of the message,
+// making it easy to identify which message failed.
+func MessageIDOnError(data Localisable, _ string, _ language.Tag, _ error) string {
+	return data.Message().ID
+}
+
+// PanicOnError is an ErrorHandlerFn that panics with the error. It is
+// intended for use in tests, so that localisation failures are not
+// masked.
+func PanicOnError(data Localisable, sourceID string, tag language.Tag, err error) string {
+	panic(NewFailedToLocaliseNativeError(data.Message().ID, sourceID, tag, err))
+}
+
+// WithErrorHandler is a UseOptionFn that sets the handler invoked when a
+// message can not be localised.
+func WithErrorHandler(handler ErrorHandlerFn) UseOptionFn {
+	return func(o *UseOptions) {
+		o.OnError = handler
+	}
+}
@@ -0,0 +1,95 @@
+package translate_test
+
+import (
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+// malformedTemplData is a message whose template can not be parsed
+type malformedTemplData struct {
+	locale.Li18ngoTemplData
+}
+
+func (td malformedTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "malformed.test",
+		Description: "Malformed",
+		Other:       "malformed {{.Name",
+	}
+}
+
+var _ = Describe("ErrorHandlers", func() {
+	BeforeEach(func() {
+		translate.ResetTx()
+	})
+
+	When("no error handler is set", func() {
+		It("🧪 should: present an empty string", func() {
+			Expect(li18ngo.Use()).To(Succeed())
+			Expect(li18ngo.Text(malformedTemplData{})).To(BeEmpty())
+		})
+	})
+
+	DescribeTable("built in handlers",
+		func(handler li18ngo.ErrorHandlerFn, expected string) {
+			Expect(li18ngo.Use(li18ngo.WithErrorHandler(handler))).To(Succeed())
+			Expect(li18ngo.Text(malformedTemplData{})).To(Equal(expected))
+		},
+		func(_ li18ngo.ErrorHandlerFn, expected string) string {
+			return "🧪 should: present '" + expected + "'"
+		},
+		Entry(nil, li18ngo.OtherOnError, "malformed {{.Name"),
+		Entry(nil, li18ngo.MessageIDOnError, "malformed.test"),
+	)
+
+	When("panic handler is set", func() {
+		It("🧪 should: panic", func() {
+			Expect(li18ngo.Use(li18ngo.WithErrorHandler(li18ngo.PanicOnError))).To(Succeed())
+			Expect(func() {
+				_ = li18ngo.Text(malformedTemplData{})
+			}).To(PanicWith(MatchError(ContainSubstring("malformed.test"))))
+		})
+	})
+
+	When("custom handler is set", func() {
+		It("🧪 should: invoke handler with failure details", func() {
+			var (
+				sourceID string
+				tag      language.Tag
+				reason   error
+			)
+
+			Expect(li18ngo.Use(li18ngo.WithErrorHandler(
+				func(_ li18ngo.Localisable, id string, t language.Tag, err error) string {
+					sourceID, tag, reason = id, t, err
+					return "failed"
+				},
+			))).To(Succeed())
+
+			Expect(li18ngo.Text(malformedTemplData{})).To(Equal("failed"))
+			Expect(sourceID).To(Equal(li18ngo.Li18ngoSourceID))
+			Expect(tag).To(Equal(li18ngo.DefaultLanguage))
+			Expect(reason).To(HaveOccurred())
+		})
+
+		It("🧪 should: not invoke handler when message is localised", func() {
+			invoked := false
+
+			Expect(li18ngo.Use(li18ngo.WithErrorHandler(
+				func(_ li18ngo.Localisable, _ string, _ language.Tag, _ error) string {
+					invoked = true
+					return ""
+				},
+			))).To(Succeed())
+
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localisation"))
+			Expect(invoked).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,85 @@
+package translate
+
+import (
+	"github.com/snivilised/li18ngo/internal/third/lo"
+)
+
+// Explain localises the message with the active translator, describing how
+// it was localised: the localizer resolved for its source, the language
+// actually matched, the translation file that defined it, or whether the
+// default message was used, and the final text. This is intended to help
+// determine why a message is not translated. The translation file is
+// determined from the files as they currently are (see Provenance) and is
+// not reported for a localizer created by a custom LocalizerCreatorFn.
+// Explain is safe to call before Use has been invoked, in which case the
+// message is explained as presented by Render.
+func Explain(data Localisable) Explanation {
+	if tx := current(); tx != nil {
+		return tx.Explain(data)
+	}
+
+	return Explanation{
+		SourceID:  data.SourceID(),
+		MessageID: data.Message().ID,
+		Localizer: ResolutionNone,
+		Default:   true,
+		Text:      data.Message().Other,
+	}
+}
+
+// Explain localises the message, describing how it was localised. Unlike
+// Localise, the message is not recorded as a missing translation.
+func (t *i18nTranslator) Explain(data Localisable) Explanation {
+	id := data.SourceID()
+	txSource, declared := t.languageInfo.From.Sources[id]
+	explanation := Explanation{
+		SourceID:  id,
+		MessageID: data.Message().ID,
+		Localizer: lo.Ternary(declared, ResolutionDeclared, ResolutionOnDemand),
+		Requested: t.languageInfo.Tag,
+		Default:   true,
+	}
+
+	localizer, err := t.mx.resolve(id)
+	if err == nil {
+		explanation.Text, explanation.Tag, err = t.mx.invoke(localizer, data, t.mx.message(data))
+	}
+
+	if err != nil {
+		explanation.Err = err
+
+		if t.languageInfo.OnError != nil {
+			explanation.Text = t.languageInfo.OnError(data, id, t.languageInfo.Tag, err)
+		}
+
+		return explanation
+	}
+
+	if !declared {
+		return explanation
+	}
+
+	// the localizers of a pseudo-locale are created for the base language
+	lang := t.languageInfo
+	if t.mx.pseudo != nil {
+		pseudo := *lang
+		pseudo.Tag = txSource.base(lang)
+		lang = &pseudo
+	}
+
+	origins, err := provenance(lang, id, t.mx.fS)
+	if err != nil {
+		explanation.Err = err
+
+		return explanation
+	}
+
+	if origin, found := origins[explanation.MessageID]; found {
+		explanation.Tag = origin.Tag
+		explanation.Path = origin.Path
+		explanation.Layer = origin.Layer
+		explanation.Default = false
+	}
+
+	return explanation
+}
@@ -0,0 +1,103 @@
+package translate_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/lab"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+var _ = Describe("Explain", func() {
+	use := func(tag language.Tag) {
+		Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+			o.Tag = tag
+			o.Supported = li18ngo.SupportedLanguages{language.French}
+			o.From = li18ngo.LoadFrom{
+				Path: lab.Repo("test/data/l10n"),
+				Sources: li18ngo.TranslationFiles{
+					li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+				},
+			}
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		translate.ResetTx()
+	})
+
+	When("message is translated", func() {
+		It("🧪 should: report the translation file", func() {
+			use(language.AmericanEnglish)
+
+			explanation := li18ngo.Explain(locale.LocalisationTemplData{})
+			Expect(explanation.SourceID).To(Equal(li18ngo.Li18ngoSourceID))
+			Expect(explanation.MessageID).To(Equal("localisation.test"))
+			Expect(explanation.Localizer).To(Equal(li18ngo.ResolutionDeclared))
+			Expect(explanation.Requested).To(Equal(language.AmericanEnglish))
+			Expect(explanation.Tag).To(Equal(language.AmericanEnglish))
+			Expect(explanation.Path).To(Equal(lab.Repo("test/data/l10n/test.active.en-US.json")))
+			Expect(explanation.Default).To(BeFalse())
+			Expect(explanation.Text).To(Equal("localization"))
+			Expect(explanation.Err).To(Succeed())
+		})
+	})
+
+	When("message is not translated", func() {
+		It("🧪 should: report the default message, without recording it as missing", func() {
+			use(language.French)
+
+			explanation := li18ngo.Explain(locale.LocalisationTemplData{})
+			Expect(explanation.Requested).To(Equal(language.French))
+			Expect(explanation.Tag).To(Equal(li18ngo.DefaultLanguage))
+			Expect(explanation.Path).To(BeEmpty())
+			Expect(explanation.Default).To(BeTrue())
+			Expect(explanation.Text).To(Equal("localisation"))
+			Expect(li18ngo.MissingTranslations()).To(BeEmpty())
+		})
+	})
+
+	When("source has not been declared", func() {
+		It("🧪 should: report the localizer created on demand", func() {
+			use(language.French)
+
+			explanation := li18ngo.Explain(ParadiseLostTemplData{})
+			Expect(explanation.Localizer).To(Equal(li18ngo.ResolutionOnDemand))
+			Expect(explanation.Default).To(BeTrue())
+			Expect(explanation.Text).To(Equal("paradise lost"))
+		})
+	})
+
+	When("Use has not been invoked", func() {
+		It("🧪 should: report the message as defined", func() {
+			explanation := li18ngo.Explain(locale.LocalisationTemplData{})
+			Expect(explanation.Localizer).To(Equal(li18ngo.ResolutionNone))
+			Expect(explanation.Default).To(BeTrue())
+			Expect(explanation.Text).To(Equal("localisation"))
+		})
+	})
+
+	When("trace is enabled", func() {
+		It("🧪 should: trace the translation files resolved", func() {
+			trace := filepath.Join(GinkgoT().TempDir(), "trace.log")
+			GinkgoT().Setenv(li18ngo.TraceEnv, trace)
+
+			use(language.AmericanEnglish)
+
+			content, err := os.ReadFile(trace)
+			Expect(err).To(Succeed())
+			Expect(string(content)).To(ContainSubstring(
+				"source 'github.com/snivilised/li18ngo': creating localizer for 'en-US'",
+			))
+			Expect(string(content)).To(ContainSubstring(
+				"loaded 8 message(s) from '" + lab.Repo("test/data/l10n/test.active.en-US.json") + "'",
+			))
+		})
+	})
+})
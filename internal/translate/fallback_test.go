@@ -0,0 +1,96 @@
+package translate_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/lab"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+var _ = Describe("Fallback", func() {
+	var (
+		from li18ngo.LoadFrom
+	)
+
+	BeforeEach(func() {
+		translate.ResetTx()
+		from = li18ngo.LoadFrom{
+			Path: lab.Repo("test/data/l10n"),
+			Sources: li18ngo.TranslationFiles{
+				li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+			},
+		}
+	})
+
+	Context("default chain", func() {
+		It("🧪 should: fall back message-by-message to the parent language", func() {
+			tx, err := li18ngo.NewTranslator(func(o *li18ngo.UseOptions) {
+				o.Tag = language.BrazilianPortuguese
+				o.Supported = li18ngo.SupportedLanguages{language.BrazilianPortuguese}
+				o.DefaultIsAcceptable = false
+				o.From = from
+			})
+			Expect(err).To(Succeed())
+
+			Expect(tx.Localise(locale.NewUsingConfigFileTemplData("jaywalk.yml"))).To(
+				Equal("Usando o arquivo de configuração: 'jaywalk.yml'"),
+				"pt-BR translation should take precedence",
+			)
+			Expect(tx.Localise(locale.InternationalisationTemplData{})).To(
+				Equal("internacionalização"),
+				"missing pt-BR translation should fall back to pt",
+			)
+			Expect(tx.Localise(locale.LocalisationTemplData{})).To(
+				Equal("localisation"),
+				"missing pt translation should fall back to base language",
+			)
+		})
+	})
+
+	Context("source supports fallback only", func() {
+		It("🧪 should: present translations of the supported fallback", func() {
+			from.Sources[li18ngo.Li18ngoSourceID] = li18ngo.TranslationSource{
+				Name:      "test",
+				Supported: li18ngo.SupportedLanguages{language.Portuguese},
+			}
+
+			tx, err := li18ngo.NewTranslator(func(o *li18ngo.UseOptions) {
+				o.Tag = language.BrazilianPortuguese
+				o.Supported = li18ngo.SupportedLanguages{language.BrazilianPortuguese}
+				o.DefaultIsAcceptable = false
+				o.From = from
+			})
+			Expect(err).To(Succeed())
+
+			Expect(tx.Localise(locale.InternationalisationTemplData{})).To(
+				Equal("internacionalização"),
+			)
+			Expect(tx.Localise(locale.NewUsingConfigFileTemplData("jaywalk.yml"))).To(
+				Equal("A utilizar o ficheiro de configuração: 'jaywalk.yml'"),
+				"unsupported pt-BR translation should not be consulted",
+			)
+		})
+	})
+
+	Context("overridden chain", func() {
+		It("🧪 should: only consult the languages of the chain", func() {
+			tx, err := li18ngo.NewTranslator(func(o *li18ngo.UseOptions) {
+				o.Tag = language.BrazilianPortuguese
+				o.Supported = li18ngo.SupportedLanguages{language.BrazilianPortuguese}
+				o.Fallbacks = li18ngo.FallbackChains{
+					language.BrazilianPortuguese: {},
+				}
+				o.From = from
+			})
+			Expect(err).To(Succeed())
+
+			Expect(tx.Localise(locale.InternationalisationTemplData{})).To(
+				Equal("internationalisation"),
+			)
+		})
+	})
+})
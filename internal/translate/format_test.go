@@ -0,0 +1,121 @@
+package translate_test
+
+import (
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+const (
+	formatAmericanEnglishJSON = `{
+  "localisation.test": {
+    "description": "Localisation",
+    "hash": "sha1-053e15971b8d428c47cdb902f90c4fcecc72e253",
+    "other": "localization (json)"
+  }
+}`
+
+	formatAmericanEnglishYAML = `localisation.test:
+  description: Localisation
+  hash: sha1-053e15971b8d428c47cdb902f90c4fcecc72e253
+  other: localization (yaml)
+`
+
+	formatFrenchTOML = `["localisation.test"]
+description = "Localisation"
+hash = "sha1-053e15971b8d428c47cdb902f90c4fcecc72e253"
+other = "localisation (toml)"
+`
+)
+
+var _ = Describe("Format", func() {
+	var formatFS fstest.MapFS
+
+	use := func(tag language.Tag, format li18ngo.Format) {
+		Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+			o.Tag = tag
+			o.DefaultIsAcceptable = false
+			o.Supported = li18ngo.SupportedLanguages{language.French}
+			o.FS = formatFS
+			o.Format = format
+			o.From = li18ngo.LoadFrom{
+				Path: "l10n",
+				Sources: li18ngo.TranslationFiles{
+					li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+				},
+			}
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		translate.ResetTx()
+
+		formatFS = fstest.MapFS{
+			"l10n/test.active.en-US.json": &fstest.MapFile{Data: []byte(formatAmericanEnglishJSON)},
+			"l10n/test.active.en-US.yaml": &fstest.MapFile{Data: []byte(formatAmericanEnglishYAML)},
+			"l10n/test.active.fr.toml":    &fstest.MapFile{Data: []byte(formatFrenchTOML)},
+		}
+	})
+
+	DescribeTable("translation files",
+		func(tag language.Tag, format li18ngo.Format, expected string) {
+			use(tag, format)
+
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal(expected))
+		},
+		func(tag language.Tag, format li18ngo.Format, expected string) string {
+			return "🧪 should: load " + tag.String() + " as '" + expected + "'"
+		},
+		Entry(nil, language.AmericanEnglish, li18ngo.Format(""), "localization (json)"),
+		Entry(nil, language.AmericanEnglish, li18ngo.FormatYAML, "localization (yaml)"),
+		Entry(nil, language.French, li18ngo.Format(""), "localisation (toml)"),
+		Entry(nil, language.French, li18ngo.FormatTOML, "localisation (toml)"),
+	)
+
+	When("source specifies its format", func() {
+		It("🧪 should: override format of translator", func() {
+			Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+				o.Tag = language.AmericanEnglish
+				o.DefaultIsAcceptable = false
+				o.FS = formatFS
+				o.Format = li18ngo.FormatJSON
+				o.From = li18ngo.LoadFrom{
+					Path: "l10n",
+					Sources: li18ngo.TranslationFiles{
+						li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{
+							Name:   "test",
+							Format: li18ngo.FormatYAML,
+						},
+					},
+				}
+			})).To(Succeed())
+
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localization (yaml)"))
+		})
+	})
+
+	When("discovering languages", func() {
+		It("🧪 should: find files of all formats", func() {
+			Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+				o.FS = formatFS
+				o.Discover = true
+				o.From = li18ngo.LoadFrom{
+					Path: "l10n",
+					Sources: li18ngo.TranslationFiles{
+						li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+					},
+				}
+			})).To(Succeed())
+
+			Expect(li18ngo.Available()[li18ngo.Li18ngoSourceID]).To(Equal(
+				li18ngo.SupportedLanguages{language.AmericanEnglish, language.French},
+			))
+		})
+	})
+})
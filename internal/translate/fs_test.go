@@ -0,0 +1,83 @@
+package translate_test
+
+import (
+	"os"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/lab"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+var _ = Describe("FS", func() {
+	var embedded fstest.MapFS
+
+	BeforeEach(func() {
+		translate.ResetTx()
+
+		// simulates translations embedded in the executable via embed.FS
+		data, err := os.ReadFile(lab.Repo("test/data/l10n/test.active.en-US.json"))
+		Expect(err).To(Succeed())
+
+		embedded = fstest.MapFS{
+			"l10n/test.active.en-US.json": &fstest.MapFile{Data: data},
+		}
+	})
+
+	When("translations are loaded from a file system", func() {
+		It("🧪 should: resolve path within the file system", func() {
+			Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+				o.Tag = language.AmericanEnglish
+				o.DefaultIsAcceptable = false
+				o.FS = embedded
+				o.From = li18ngo.LoadFrom{
+					Path: "l10n",
+					Sources: li18ngo.TranslationFiles{
+						li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+					},
+				}
+			})).To(Succeed())
+
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localization"))
+		})
+
+		It("🧪 should: resolve source path within the file system", func() {
+			Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+				o.Tag = language.AmericanEnglish
+				o.DefaultIsAcceptable = false
+				o.FS = embedded
+				o.From = li18ngo.LoadFrom{
+					Sources: li18ngo.TranslationFiles{
+						li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{
+							Name: "test",
+							Path: "l10n",
+						},
+					},
+				}
+			})).To(Succeed())
+
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localization"))
+		})
+	})
+
+	When("path does not exist within the file system", func() {
+		It("🧪 should: not consult the host file system", func() {
+			Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+				o.Tag = language.AmericanEnglish
+				o.DefaultIsAcceptable = false
+				o.FS = embedded
+				o.From = li18ngo.LoadFrom{
+					Path: lab.Repo("test/data/l10n"),
+					Sources: li18ngo.TranslationFiles{
+						li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+					},
+				}
+			})).NotTo(Succeed())
+		})
+	})
+})
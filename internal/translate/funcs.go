@@ -0,0 +1,167 @@
+package translate
+
+import (
+	"strings"
+	"text/template"
+	"time"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+var (
+	// dateLayouts are the layouts of dates, keyed by region, for the regions
+	// whose convention differs from the default, day/month/year
+	dateLayouts = map[string]string{
+		"US": "01/02/2006",
+		"CN": "2006/01/02", "JP": "2006/01/02", "TW": "2006/01/02", "KR": "2006. 01. 02.",
+		"CA": "2006-01-02", "SE": "2006-01-02", "LT": "2006-01-02",
+		"DE": "02.01.2006", "AT": "02.01.2006", "CH": "02.01.2006", "RU": "02.01.2006",
+		"PL": "02.01.2006", "NO": "02.01.2006", "FI": "02.01.2006", "TR": "02.01.2006",
+		"CZ": "02.01.2006", "NL": "02-01-2006",
+	}
+
+	// byteUnits are the SI units of byte counts, in increasing magnitude
+	byteUnits = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+)
+
+const (
+	// defaultDateLayout is the layout of dates for regions without an entry
+	// in dateLayouts
+	defaultDateLayout = "02/01/2006"
+)
+
+// TemplateFuncs returns the template functions, available to every message
+// template, that format the value of a field according to the conventions of
+// the language:
+//
+//   - number: a number with grouped digits, eg {{number .Count}} => 1,234,567
+//   - percent: a ratio as a percentage, eg {{percent .Ratio}} => 25%
+//   - currency: an amount in the currency of the language's region
+//   - date: a time.Time as a numeric date, eg 31/12/2025
+//   - duration: a time.Duration in hours, minutes and seconds, eg 1h 30m
+//   - bytes: a byte count in SI units, eg 1.5 MB
+//
+// lingo emits these functions for the fields of a message that declare a
+// Format.
+func TemplateFuncs(tag language.Tag) template.FuncMap {
+	printer := message.NewPrinter(tag)
+	unit, _ := currency.FromTag(tag)
+	region, _ := tag.Region()
+	layout, found := dateLayouts[region.String()]
+
+	if !found {
+		layout = defaultDateLayout
+	}
+
+	return template.FuncMap{
+		"number": func(v any) string {
+			return printer.Sprint(number.Decimal(v))
+		},
+		"percent": func(v any) string {
+			return printer.Sprint(number.Percent(v))
+		},
+		"currency": func(v any) string {
+			return printer.Sprint(currency.Symbol(unit.Amount(v)))
+		},
+		"date": func(t time.Time) string {
+			return t.Format(layout)
+		},
+		"duration": func(d time.Duration) string {
+			return formatDuration(printer, d)
+		},
+		"bytes": func(v any) string {
+			return formatBytes(printer, v)
+		},
+	}
+}
+
+// formatDuration formats the duration in hours, minutes and seconds, omitting
+// the components that are zero. A duration of less than a second is
+// presented in milliseconds.
+func formatDuration(printer *message.Printer, d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+
+	if d < time.Second {
+		return sign + printer.Sprint(number.Decimal(
+			float64(d)/float64(time.Millisecond), number.MaxFractionDigits(3),
+		)) + "ms"
+	}
+
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+
+	var parts []string
+
+	if hours > 0 {
+		parts = append(parts, printer.Sprint(number.Decimal(int64(hours)))+"h")
+	}
+
+	if minutes > 0 {
+		parts = append(parts, printer.Sprint(number.Decimal(int64(minutes)))+"m")
+	}
+
+	if d > 0 {
+		parts = append(parts, printer.Sprint(number.Decimal(
+			d.Seconds(), number.MaxFractionDigits(3),
+		))+"s")
+	}
+
+	return sign + strings.Join(parts, " ")
+}
+
+// formatBytes formats the byte count in the largest SI unit it amounts to
+// at least one of, to 1 decimal place.
+func formatBytes(printer *message.Printer, v any) string {
+	size, ok := toFloat(v)
+	if !ok {
+		return printer.Sprint(v)
+	}
+
+	magnitude := 0
+	for ; magnitude < len(byteUnits)-1 && (size >= 1000 || size <= -1000); magnitude++ {
+		size /= 1000
+	}
+
+	return printer.Sprint(number.Decimal(size, number.MaxFractionDigits(1))) +
+		" " + byteUnits[magnitude]
+}
+
+// toFloat converts a value of a numeric type to a float64
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+
+	return 0, false
+}
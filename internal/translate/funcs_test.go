@@ -0,0 +1,97 @@
+package translate_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+// filesCopiedTemplData is a message whose fields declare a Format, as
+// generated by lingo
+type filesCopiedTemplData struct {
+	locale.Li18ngoTemplData
+	Count int
+	Size  int64
+}
+
+func (td filesCopiedTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "files-copied.format.test",
+		Description: "Number of files copied",
+		Other:       "{{number .Count}} files copied ({{bytes .Size}})",
+	}
+}
+
+var _ = Describe("TemplateFuncs", func() {
+	BeforeEach(func() {
+		translate.ResetTx()
+	})
+
+	DescribeTable("format",
+		func(tag language.Tag, format string, value any, expected string) {
+			funcs := li18ngo.TemplateFuncs(tag)
+			Expect(funcs).To(HaveKey(format))
+
+			result := fmt.Sprint(funcs[format].(func(any) string)(value))
+			Expect(result).To(Equal(expected))
+		},
+		func(tag language.Tag, format string, value any, expected string) string {
+			return fmt.Sprintf("🧪 should: format %v for %v: '%v'", format, tag, expected)
+		},
+		Entry(nil, language.BritishEnglish, "number", 1234567, "1,234,567"),
+		Entry(nil, language.MustParse("de-DE"), "number", 1234567, "1.234.567"),
+		Entry(nil, language.BritishEnglish, "percent", 0.25, "25%"),
+		Entry(nil, language.BritishEnglish, "bytes", 999, "999 B"),
+		Entry(nil, language.BritishEnglish, "bytes", int64(1_500_000), "1.5 MB"),
+		Entry(nil, language.MustParse("de-DE"), "bytes", int64(1_500_000), "1,5 MB"),
+	)
+
+	DescribeTable("date",
+		func(tag language.Tag, expected string) {
+			date := li18ngo.TemplateFuncs(tag)["date"].(func(time.Time) string)
+			Expect(date(time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC))).To(Equal(expected))
+		},
+		func(tag language.Tag, expected string) string {
+			return fmt.Sprintf("🧪 should: format date for %v: '%v'", tag, expected)
+		},
+		Entry(nil, language.BritishEnglish, "31/12/2025"),
+		Entry(nil, language.AmericanEnglish, "12/31/2025"),
+		Entry(nil, language.MustParse("de-DE"), "31.12.2025"),
+	)
+
+	DescribeTable("duration",
+		func(d time.Duration, expected string) {
+			duration := li18ngo.TemplateFuncs(language.BritishEnglish)["duration"].(func(time.Duration) string)
+			Expect(duration(d)).To(Equal(expected))
+		},
+		func(d time.Duration, expected string) string {
+			return fmt.Sprintf("🧪 should: format duration %v: '%v'", d, expected)
+		},
+		Entry(nil, 90*time.Minute+5*time.Second, "1h 30m 5s"),
+		Entry(nil, 2*time.Hour, "2h"),
+		Entry(nil, 1500*time.Millisecond, "1.5s"),
+		Entry(nil, 250*time.Millisecond, "250ms"),
+	)
+
+	When("message declares formatted fields", func() {
+		It("🧪 should: format fields for the language of the translator", func() {
+			tx, err := li18ngo.NewTranslator(func(o *li18ngo.UseOptions) {
+				o.Tag = language.German
+				o.Supported = li18ngo.SupportedLanguages{language.German}
+			})
+			Expect(err).To(Succeed())
+
+			Expect(tx.Localise(filesCopiedTemplData{Count: 1234, Size: 2_500_000})).To(
+				Equal("1.234 files copied (2,5 MB)"),
+			)
+		})
+	})
+})
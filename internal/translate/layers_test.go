@@ -0,0 +1,170 @@
+package translate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/lab"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+const (
+	siteAmericanEnglish = `{
+  "internationalisation.test": {
+    "description": "Internationalisation",
+    "hash": "sha1-8dd7952545d8104150f6d66d3e0f3c650b44c072",
+    "other": "internationalization (site)"
+  },
+  "localisation.test": {
+    "description": "Localisation",
+    "hash": "sha1-053e15971b8d428c47cdb902f90c4fcecc72e253",
+    "other": "localization (site)"
+  }
+}`
+
+	userAmericanEnglish = `{
+  "localisation.test": {
+    "description": "Localisation",
+    "hash": "sha1-053e15971b8d428c47cdb902f90c4fcecc72e253",
+    "other": "localization (user)"
+  }
+}`
+
+	siteBritishEnglish = `{
+  "localisation.test": {
+    "description": "Localisation",
+    "hash": "sha1-053e15971b8d428c47cdb902f90c4fcecc72e253",
+    "other": "localisation (site)"
+  }
+}`
+)
+
+var _ = Describe("Layers", func() {
+	var (
+		sitePath string
+		userFS   fstest.MapFS
+		shipped  fstest.MapFS
+	)
+
+	use := func(tag language.Tag) {
+		Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+			o.Tag = tag
+			o.DefaultIsAcceptable = false
+			o.FS = shipped
+			o.From = li18ngo.LoadFrom{
+				Path: "l10n",
+				Sources: li18ngo.TranslationFiles{
+					li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{
+						Name: "test",
+						Layers: []li18ngo.Layer{
+							{Name: "site", Path: sitePath},
+							{Name: "user", Path: "l10n", FS: userFS},
+						},
+					},
+				},
+			}
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		translate.ResetTx()
+
+		data, err := os.ReadFile(lab.Repo("test/data/l10n/test.active.en-US.json"))
+		Expect(err).To(Succeed())
+
+		shipped = fstest.MapFS{
+			"l10n/test.active.en-US.json": &fstest.MapFile{Data: data},
+		}
+
+		sitePath = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(sitePath, "test.active.en-US.json"),
+			[]byte(siteAmericanEnglish), 0o600,
+		)).To(Succeed())
+
+		userFS = fstest.MapFS{
+			"l10n/test.active.en-US.json": &fstest.MapFile{Data: []byte(userAmericanEnglish)},
+		}
+	})
+
+	When("layers override the source's translations", func() {
+		It("🧪 should: present message of the last layer that defines it", func() {
+			use(language.AmericanEnglish)
+
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localization (user)"))
+			Expect(li18ngo.Text(locale.InternationalisationTemplData{})).To(
+				Equal("internationalization (site)"),
+			)
+			Expect(li18ngo.Text(locale.UsingConfigFileTemplData{
+				ConfigFileName: "jupiter",
+			})).To(Equal("Using config file: jupiter"),
+				"message not overridden should be presented from the source's own translations",
+			)
+		})
+
+		It("🧪 should: report origin of each message", func() {
+			use(language.AmericanEnglish)
+
+			origins, err := li18ngo.Provenance(li18ngo.Li18ngoSourceID)
+			Expect(err).To(Succeed())
+
+			Expect(origins["localisation.test"]).To(Equal(li18ngo.MessageOrigin{
+				Layer: "user",
+				Tag:   language.AmericanEnglish,
+				Path:  "l10n/test.active.en-US.json",
+			}))
+			Expect(origins["internationalisation.test"]).To(Equal(li18ngo.MessageOrigin{
+				Layer: "site",
+				Tag:   language.AmericanEnglish,
+				Path:  filepath.Join(sitePath, "test.active.en-US.json"),
+			}))
+			Expect(origins["using-config-file"]).To(Equal(li18ngo.MessageOrigin{
+				Tag:  language.AmericanEnglish,
+				Path: "l10n/test.active.en-US.json",
+			}))
+		})
+	})
+
+	When("layer overrides the base language", func() {
+		It("🧪 should: present overridden message", func() {
+			Expect(os.WriteFile(filepath.Join(sitePath, "test.active.en-GB.json"),
+				[]byte(siteBritishEnglish), 0o600,
+			)).To(Succeed())
+
+			use(language.BritishEnglish)
+
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localisation (site)"))
+			Expect(li18ngo.Text(locale.InternationalisationTemplData{})).To(
+				Equal("internationalisation"),
+			)
+		})
+	})
+
+	When("layer file is malformed", func() {
+		It("🧪 should: fail", func() {
+			Expect(os.WriteFile(filepath.Join(sitePath, "test.active.en-US.json"),
+				[]byte("{ malformed"), 0o600,
+			)).To(Succeed())
+
+			Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+				o.Tag = language.AmericanEnglish
+				o.FS = shipped
+				o.From = li18ngo.LoadFrom{
+					Path: "l10n",
+					Sources: li18ngo.TranslationFiles{
+						li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{
+							Name:   "test",
+							Layers: []li18ngo.Layer{{Name: "site", Path: sitePath}},
+						},
+					},
+				}
+			})).NotTo(Succeed())
+		})
+	})
+})
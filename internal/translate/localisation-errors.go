@@ -45,6 +45,17 @@ func NewFailedToCreateTranslatorNativeError(tag language.Tag) error {
 	)
 }
 
+// ❌ Failed To Localise
+
+// NewFailedToLocaliseNativeError creates an untranslated error to
+// indicate that a message could not be localised
+func NewFailedToLocaliseNativeError(messageID, sourceID string, tag language.Tag, reason error) error {
+	return errors.Wrapf(
+		reason, "i18n: failed to localise message '%v' (source: '%v', language: '%v')",
+		messageID, sourceID, tag,
+	)
+}
+
 var ErrInvalidTranslator = errors.New(
 	"i18n: invalid incoming translator instance (not i18nTranslator)",
 )
@@ -2,67 +2,393 @@ package translate
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 
+	"github.com/BurntSushi/toml"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/snivilised/li18ngo/internal/third/lo"
 	nef "github.com/snivilised/nefilim"
+	"go.yaml.in/yaml/v3"
 	"golang.org/x/text/language"
 )
 
+var (
+	// unmarshalers maps the format of a translation file to the function that
+	// decodes it
+	unmarshalers = map[string]i18n.UnmarshalFunc{
+		string(FormatJSON): json.Unmarshal,
+		string(FormatYAML): yaml.Unmarshal,
+		string(FormatTOML): toml.Unmarshal,
+	}
+
+	// formats are the formats of translation files, in order of precedence
+	// when detected
+	formats = []Format{FormatJSON, FormatYAML, FormatTOML}
+)
+
 func createLocalizer(lang *LanguageInfo, sourceID string,
-	fS nef.ReaderFS,
+	fS fs.FS,
 ) (*i18n.Localizer, error) {
-	bundle := i18n.NewBundle(lang.Tag)
-	bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
+	txSource := lang.From.Sources[sourceID]
+	base := txSource.base(lang)
 
-	if lang.Tag != lang.Default {
-		txSource := lang.From.Sources[sourceID]
-		path := resolveBundlePath(lang, txSource, fS)
-		_, err := bundle.LoadMessageFile(path)
+	// The bundle's default language is the source's base language, so that
+	// go-i18n can report when a message has not been translated and is
+	// therefore presented in the base language instead.
+	bundle := i18n.NewBundle(base)
 
-		if (err != nil) && (!lang.DefaultIsAcceptable) {
-			return nil, NewCouldNotLoadTranslationsNativeError(lang.Tag, path, err)
-		}
+	tracef("source '%v': creating localizer for '%v', base language '%v'",
+		sourceID, lang.Tag, base,
+	)
+
+	err := loadBundle(lang, sourceID, fS, func(file bundleFile, messages []*i18n.Message) error {
+		return bundle.AddMessages(lang.Tag, messages...)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	supported := lo.Map(lang.Supported, func(t language.Tag, _ int) string {
-		return t.String()
+	return i18n.NewLocalizer(bundle, lang.Tag.String()), nil
+}
+
+// provenance returns the origin of each message translated for the source,
+// by loading its translation files in the same manner as createLocalizer.
+func provenance(lang *LanguageInfo, sourceID string, fS fs.FS) (map[string]MessageOrigin, error) {
+	txSource := lang.From.Sources[sourceID]
+	origins := make(map[string]MessageOrigin)
+
+	err := loadBundle(lang, sourceID, fS, func(file bundleFile, messages []*i18n.Message) error {
+		origin := MessageOrigin{
+			Layer: lo.TernaryF(file.layer == 0,
+				func() string {
+					return ""
+				},
+				func() string {
+					return txSource.Layers[file.layer-1].Name
+				},
+			),
+			Tag:  file.tag,
+			Path: file.path,
+		}
+
+		for _, message := range messages {
+			origins[message.ID] = origin
+		}
+
+		return nil
 	})
 
-	return i18n.NewLocalizer(bundle, supported...), nil
+	return origins, err
 }
 
-// returns an absolute reference to the bundle file
-func resolveBundlePath(lang *LanguageInfo, txSource TranslationSource,
-	fS nef.ReaderFS,
+// loadBundle loads the translation files of the source in order, passing the
+// messages of each to the visit function. The files are loaded from the
+// most general language to the most specific and all under the requested
+// tag, so that a message defined for a more specific language overrides
+// that of its fallback; likewise for each layer in turn.
+func loadBundle(lang *LanguageInfo, sourceID string, fS fs.FS,
+	visit func(file bundleFile, messages []*i18n.Message) error,
+) error {
+	for _, file := range bundleFiles(lang, sourceID, fS) {
+		messages, err := loadMessages(file)
+
+		if err != nil {
+			missing := errors.Is(err, fs.ErrNotExist)
+
+			tracef("source '%v': %v '%v' [%v] (%v)", sourceID,
+				lo.Ternary(missing, "no translation file", "could not load"),
+				file.path, file.tag, lo.Ternary(file.layer > 0, "layer", "source"),
+			)
+
+			// a layer need only contain the files for the languages it
+			// overrides, but those it does contain must be valid.
+			if file.layer > 0 && missing {
+				continue
+			}
+
+			if file.layer > 0 || (file.tag == lang.Tag && !lang.DefaultIsAcceptable) ||
+				(lang.strict && !missing) {
+				return NewCouldNotLoadTranslationsNativeError(file.tag, file.path, err)
+			}
+
+			continue
+		}
+
+		tracef("source '%v': loaded %v message(s) from '%v' [%v] (%v)", sourceID,
+			len(messages), file.path, file.tag, lo.Ternary(file.layer > 0, "layer", "source"),
+		)
+
+		if lang.pseudo != nil {
+			for i, message := range messages {
+				messages[i] = lang.pseudo.message(message)
+			}
+		}
+
+		if err := visit(file, messages); err != nil {
+			return NewCouldNotLoadTranslationsNativeError(file.tag, file.path, err)
+		}
+	}
+
+	return nil
+}
+
+// bundleFile is a translation file consulted when creating the localizer
+// for a source.
+type bundleFile struct {
+	tag  language.Tag
+	path string
+
+	// fS is the file system the file is loaded from
+	fS fs.FS
+
+	// layer is the position of the file's layer in the source's Layers,
+	// counting from 1; 0 denotes the source's own translations.
+	layer int
+
+	// fixed denotes a file of a file system provided by a source or layer,
+	// which is typically embedded and therefore does not change.
+	fixed bool
+}
+
+// bundleFiles returns the translation files consulted for the source, in the
+// requested language and its fallbacks, ordered from the most general
+// language to the most specific, followed by those of each layer.
+func bundleFiles(lang *LanguageInfo, sourceID string, fS fs.FS) []bundleFile {
+	txSource := lang.From.Sources[sourceID]
+	base := txSource.base(lang)
+
+	// translations are not required when the requested language is the one
+	// the source's messages are authored in, but they may still be
+	// overridden by a layer.
+	if lang.Tag == base {
+		tracef("source '%v': '%v' is the base language, only layers are consulted",
+			sourceID, base,
+		)
+
+		return layerFiles(lang, txSource, []language.Tag{base})
+	}
+
+	// each language of the chain is filtered individually, so that a source
+	// supporting a fallback, but not the requested language itself, still
+	// contributes the translations of that fallback.
+	var chain []language.Tag
+
+	for _, tag := range lang.fallbackChain(lang.Tag, base) {
+		if !txSource.supports(tag) {
+			tracef("source '%v': '%v' is not supported, its translation files are not consulted",
+				sourceID, tag,
+			)
+
+			continue
+		}
+
+		chain = append(chain, tag)
+	}
+
+	files := make([]bundleFile, 0, len(chain))
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		files = append(files, bundleFile{
+			tag:   chain[i],
+			path:  resolveBundlePath(lang, chain[i], txSource, fS),
+			fS:    lo.Ternary(txSource.FS != nil, txSource.FS, fS),
+			fixed: txSource.FS != nil,
+		})
+	}
+
+	return append(files, layerFiles(lang, txSource, chain)...)
+}
+
+// layerFiles returns the translation files of the source's layers, for the
+// languages of the chain, ordered from the most general language to the most
+// specific.
+func layerFiles(lang *LanguageInfo, txSource TranslationSource,
+	chain []language.Tag,
+) []bundleFile {
+	files := make([]bundleFile, 0, len(txSource.Layers)*len(chain))
+
+	for l, layer := range txSource.Layers {
+		for i := len(chain) - 1; i >= 0; i-- {
+			files = append(files, lo.TernaryF(layer.FS != nil,
+				func() bundleFile {
+					directory := path.Clean(lo.Ternary(layer.Path == "", ".", layer.Path))
+
+					return bundleFile{
+						tag:   chain[i],
+						path:  locate(lang, txSource, chain[i], layer.FS, path.Join, directory),
+						fS:    layer.FS,
+						layer: l + 1,
+						fixed: true,
+					}
+				},
+				func() bundleFile {
+					directory, _ := filepath.Abs(layer.Path)
+					hostFS := nef.NewReaderABS()
+
+					return bundleFile{
+						tag:   chain[i],
+						path:  locate(lang, txSource, chain[i], hostFS, filepath.Join, directory),
+						fS:    hostFS,
+						layer: l + 1,
+					}
+				},
+			))
+		}
+	}
+
+	return files
+}
+
+// loadMessages reads the messages from the translation file. This is the
+// equivalent of Bundle.LoadMessageFileFS, except that the language of the
+// messages is not derived from the file name, as the files of a fallback
+// chain are all loaded under the requested language.
+func loadMessages(file bundleFile) ([]*i18n.Message, error) {
+	buf, err := fs.ReadFile(file.fS, file.path)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := i18n.ParseMessageFileBytes(buf, file.path, unmarshalers)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsed.Messages, nil
+}
+
+// returns a reference to the bundle file. For translations loaded from the
+// host file system, the reference is absolute, otherwise it is the path of
+// the file within the file system, see resolveDirectory.
+func resolveBundlePath(lang *LanguageInfo, tag language.Tag,
+	txSource TranslationSource, fS fs.FS,
+) string {
+	directory := resolveDirectory(lang, txSource, fS)
+	fS = lo.Ternary(txSource.FS != nil, txSource.FS, fS)
+
+	if hosted(lang, txSource) {
+		return locate(lang, txSource, tag, fS, filepath.Join, directory)
+	}
+
+	return locate(lang, txSource, tag, fS, path.Join, directory)
+}
+
+// locate returns the path of the source's translation file for the language
+// specified, within the directory. When the format has not been specified,
+// the first format of which a file exists is selected; json is assumed if
+// there is none.
+func locate(lang *LanguageInfo, txSource TranslationSource, tag language.Tag,
+	fS fs.FS, join func(elem ...string) string, directory string,
+) string {
+	if format := txSource.format(lang); format != "" {
+		return join(directory, bundleName(txSource, tag, format))
+	}
+
+	for _, format := range formats {
+		candidate := join(directory, bundleName(txSource, tag, format))
+
+		if _, err := fs.Stat(fS, candidate); err == nil {
+			tracef("'%v%v.*': detected format '%v' in '%v'",
+				activePrefix(txSource), tag, format, directory,
+			)
+
+			return candidate
+		}
+	}
+
+	return join(directory, bundleName(txSource, tag, FormatJSON))
+}
+
+// returns a reference to the directory containing the source's translation
+// files. For a source with its own file system, this is the source's Path
+// within that file system. For a translator with a file system (UseOptions.FS),
+// this is the first of the source's Path and the From.Path that exists within
+// that file system, otherwise its root; the host file system is not consulted,
+// so that an embed.FS can be used. Otherwise, for the host file system, it is
+// the absolute path of the first of these that exists, or failing that, the
+// directory of the executable.
+func resolveDirectory(lang *LanguageInfo, txSource TranslationSource,
+	fS fs.FS,
 ) string {
-	path := lo.Ternary(txSource.Path != "" && fS.DirectoryExists(txSource.Path),
+	prefix := activePrefix(txSource)
+
+	if txSource.FS != nil {
+		directory := path.Clean(lo.Ternary(txSource.Path == "", ".", txSource.Path))
+		tracef("'%v*': directory '%v' of the source's file system", prefix, directory)
+
+		return directory
+	}
+
+	directory := lo.Ternary(txSource.Path != "" && isDirectory(fS, txSource.Path),
 		txSource.Path,
 		lang.From.Path,
 	)
+	exists := directory != "" && isDirectory(fS, directory)
+
+	if !hosted(lang, txSource) {
+		if !exists {
+			tracef("'%v*': neither Path '%v' nor From.Path '%v' exists in the translator's file system, using its root",
+				prefix, txSource.Path, lang.From.Path,
+			)
+
+			return "."
+		}
+
+		tracef("'%v*': directory '%v' of the translator's file system", prefix, path.Clean(directory))
 
-	directory := lo.TernaryF(path != "" && fS.DirectoryExists(path),
+		return path.Clean(directory)
+	}
+
+	return lo.TernaryF(exists,
 		func() string {
-			resolved, _ := filepath.Abs(path)
+			resolved, _ := filepath.Abs(directory)
+			tracef("'%v*': directory '%v'", prefix, resolved)
+
 			return resolved
 		},
 		func() string {
 			exe, _ := os.Executable()
+			tracef("'%v*': neither Path '%v' nor From.Path '%v' exists, falling back to the executable directory '%v'",
+				prefix, txSource.Path, lang.From.Path, filepath.Dir(exe),
+			)
+
 			return filepath.Dir(exe)
 		},
 	)
+}
+
+// hosted determines whether the source's translations are loaded from the
+// host file system
+func hosted(lang *LanguageInfo, txSource TranslationSource) bool {
+	return txSource.FS == nil && lang.FS == nil
+}
 
-	filename := lo.TernaryF(txSource.Name == "",
+func isDirectory(fS fs.FS, name string) bool {
+	info, err := fs.Stat(fS, name)
+
+	return err == nil && info.IsDir()
+}
+
+// bundleName returns the name of the source's translation file for the
+// language and format specified
+func bundleName(txSource TranslationSource, tag language.Tag, format Format) string {
+	return fmt.Sprintf("%v%v.%v", activePrefix(txSource), tag, format)
+}
+
+// activePrefix returns the portion of the translation file name that
+// precedes the language tag, ie '<name>.active.'
+func activePrefix(txSource TranslationSource) string {
+	return lo.TernaryF(txSource.Name == "",
 		func() string {
-			return fmt.Sprintf("active.%v.json", lang.Tag)
+			return "active."
 		},
 		func() string {
-			return fmt.Sprintf("%v.active.%v.json", txSource.Name, lang.Tag)
+			return fmt.Sprintf("%v.active.", txSource.Name)
 		},
 	)
-
-	return filepath.Join(directory, filename)
 }
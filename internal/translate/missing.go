@@ -0,0 +1,151 @@
+package translate
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// defaultDumpInterval is the interval at which the missing translations are
+// written, when not specified to DumpMissingTranslations.
+const defaultDumpInterval = time.Minute
+
+// defaultCollector is the collector used by translators that have not been
+// provided with one.
+var defaultCollector = &memoryCollector{}
+
+type missingKey struct {
+	sourceID  string
+	messageID string
+	tag       language.Tag
+}
+
+// memoryCollector is an in-memory MissingCollector that counts the number of
+// times each message is presented untranslated.
+type memoryCollector struct {
+	entries sync.Map // missingKey => *atomic.Int64
+}
+
+// NewMissingCollector creates an in-memory MissingCollector.
+func NewMissingCollector() MissingCollector {
+	return &memoryCollector{}
+}
+
+func (c *memoryCollector) Collect(sourceID, messageID string, tag language.Tag) {
+	key := missingKey{sourceID: sourceID, messageID: messageID, tag: tag}
+	count, found := c.entries.Load(key)
+
+	if !found {
+		count, _ = c.entries.LoadOrStore(key, &atomic.Int64{})
+	}
+
+	count.(*atomic.Int64).Add(1)
+}
+
+// Snapshot returns the missing translations ordered by source, language and
+// message id.
+func (c *memoryCollector) Snapshot() []MissingTranslation {
+	var snapshot []MissingTranslation
+
+	c.entries.Range(func(k, v any) bool {
+		key := k.(missingKey)
+		snapshot = append(snapshot, MissingTranslation{
+			SourceID:  key.sourceID,
+			MessageID: key.messageID,
+			Tag:       key.tag,
+			Count:     v.(*atomic.Int64).Load(),
+		})
+
+		return true
+	})
+
+	slices.SortFunc(snapshot, func(a, b MissingTranslation) int {
+		return cmp.Or(
+			cmp.Compare(a.SourceID, b.SourceID),
+			cmp.Compare(a.Tag.String(), b.Tag.String()),
+			cmp.Compare(a.MessageID, b.MessageID),
+		)
+	})
+
+	return snapshot
+}
+
+func (c *memoryCollector) reset() {
+	c.entries.Clear()
+}
+
+// MissingTranslations returns a snapshot of the messages that have been
+// presented untranslated by the active translator, because a translation
+// for the requested language is not available.
+func MissingTranslations() []MissingTranslation {
+	if tx, ok := current().(*i18nTranslator); ok {
+		return tx.mx.collector.Snapshot()
+	}
+
+	return defaultCollector.Snapshot()
+}
+
+// DumpMissingTranslations periodically writes the missing translations of the
+// active translator, as json, to the file at path, until the context is
+// cancelled, at which point a final dump is written. The file is replaced
+// atomically, so a partially written file is never observed. Intended to be
+// invoked on its own goroutine; returns the error of the first failed dump.
+// An interval that is not positive denotes the default of a minute.
+func DumpMissingTranslations(ctx context.Context, path string, interval time.Duration) error {
+	if interval <= 0 {
+		interval = defaultDumpInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return writeMissingTranslations(path)
+
+		case <-ticker.C:
+			if err := writeMissingTranslations(path); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeMissingTranslations(path string) error {
+	snapshot := MissingTranslations()
+
+	if snapshot == nil {
+		snapshot = []MissingTranslation{}
+	}
+
+	content, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name()) //nolint:errcheck // removal fails once renamed
+
+	if _, err := temp.Write(content); err != nil {
+		_ = temp.Close()
+		return err
+	}
+
+	if err := temp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), path)
+}
@@ -0,0 +1,119 @@
+package translate_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/lab"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+var _ = Describe("MissingTranslations", func() {
+	var from li18ngo.LoadFrom
+
+	BeforeEach(func() {
+		translate.ResetTx()
+		from = li18ngo.LoadFrom{
+			Path: lab.Repo("test/data/l10n"),
+			Sources: li18ngo.TranslationFiles{
+				li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+			},
+		}
+	})
+
+	When("message is not translated", func() {
+		It("🧪 should: record message presented in base language", func() {
+			Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+				o.Tag = language.French
+				o.Supported = li18ngo.SupportedLanguages{language.French}
+				o.From = from
+			})).To(Succeed())
+
+			_ = li18ngo.Text(locale.NewUsingConfigFileTemplData("jaywalk.yml"))
+			_ = li18ngo.Text(locale.LocalisationTemplData{})
+			_ = li18ngo.Text(locale.LocalisationTemplData{})
+
+			Expect(li18ngo.MissingTranslations()).To(Equal([]li18ngo.MissingTranslation{
+				{
+					SourceID:  li18ngo.Li18ngoSourceID,
+					MessageID: "localisation.test",
+					Tag:       language.French,
+					Count:     2,
+				},
+			}))
+		})
+	})
+
+	When("requested language is the base language", func() {
+		It("🧪 should: not record anything", func() {
+			Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+				o.From = from
+			})).To(Succeed())
+
+			_ = li18ngo.Text(locale.LocalisationTemplData{})
+
+			Expect(li18ngo.MissingTranslations()).To(BeEmpty())
+		})
+	})
+
+	When("collector is provided", func() {
+		It("🧪 should: record into the collector", func() {
+			collector := li18ngo.NewMissingCollector()
+			tx, err := li18ngo.NewTranslator(func(o *li18ngo.UseOptions) {
+				o.Tag = language.French
+				o.Supported = li18ngo.SupportedLanguages{language.French}
+				o.From = from
+				o.Collector = collector
+			})
+			Expect(err).To(Succeed())
+
+			_ = tx.Localise(locale.InternationalisationTemplData{})
+
+			Expect(collector.Snapshot()).To(HaveLen(1))
+			Expect(li18ngo.MissingTranslations()).To(BeEmpty())
+		})
+	})
+
+	Context("DumpMissingTranslations", func() {
+		It("🧪 should: write missing translations when cancelled", func(specCtx SpecContext) {
+			Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+				o.Tag = language.French
+				o.Supported = li18ngo.SupportedLanguages{language.French}
+				o.From = from
+			})).To(Succeed())
+
+			_ = li18ngo.Text(locale.LocalisationTemplData{})
+
+			path := filepath.Join(GinkgoT().TempDir(), "missing.json")
+			ctx, cancel := context.WithCancel(specCtx)
+			cancel()
+
+			Expect(li18ngo.DumpMissingTranslations(ctx, path, time.Hour)).To(Succeed())
+
+			content, err := os.ReadFile(path)
+			Expect(err).To(Succeed())
+
+			var dumped []li18ngo.MissingTranslation
+			Expect(json.Unmarshal(content, &dumped)).To(Succeed())
+			Expect(dumped).To(Equal(li18ngo.MissingTranslations()))
+		})
+
+		It("🧪 should: use default interval when not positive", func(specCtx SpecContext) {
+			path := filepath.Join(GinkgoT().TempDir(), "missing.json")
+			ctx, cancel := context.WithCancel(specCtx)
+			cancel()
+
+			Expect(li18ngo.DumpMissingTranslations(ctx, path, 0)).To(Succeed())
+			Expect(path).To(BeAnExistingFile())
+		})
+	})
+})
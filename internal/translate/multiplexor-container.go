@@ -1,75 +1,213 @@
 package translate
 
 import (
+	"errors"
+	"io/fs"
+	"maps"
+	"sync/atomic"
+	"text/template"
+
 	"github.com/nicksnyder/go-i18n/v2/i18n"
-	nef "github.com/snivilised/nefilim"
+	"github.com/snivilised/li18ngo/internal/third/lo"
+	"golang.org/x/text/language"
 )
 
 type multiplexor struct {
+	// funcs are the template functions of the language, see TemplateFuncs
+	funcs template.FuncMap
 }
 
-func (mx *multiplexor) invoke(localizer *i18n.Localizer, data Localisable) (string, error) {
-	return localizer.Localize(&i18n.LocalizeConfig{
-		DefaultMessage: data.Message(),
+// invoke localises the message, returning the language of the text produced.
+// A message that has not been translated, is presented in the base language
+// of its source, which go-i18n reports as a MessageNotFoundErr; this is not
+// regarded as an error, as the text is still available.
+func (mx *multiplexor) invoke(localizer *i18n.Localizer, data Localisable,
+	message *i18n.Message,
+) (string, language.Tag, error) {
+	config := &i18n.LocalizeConfig{
+		DefaultMessage: message,
 		TemplateData:   data,
-	})
+		Funcs:          mx.funcs,
+	}
+
+	if countable, ok := data.(Countable); ok {
+		config.PluralCount = countable.PluralCount()
+	}
+
+	text, tag, err := localizer.LocalizeWithTag(config)
+
+	var notFound *i18n.MessageNotFoundErr
+	if tag != language.Und && errors.As(err, &notFound) {
+		err = nil
+	}
+
+	return text, tag, err
 }
 
+// multiContainer maps source ids to their localizers. The map is never
+// modified once published; updates are performed copy-on-write and swapped
+// in atomically, so that localise can be invoked concurrently without
+// locking.
 type multiContainer struct {
 	multiplexor
-	localizers localizerContainer
-	queryFS    nef.ReaderFS
-	fS         nef.ReaderFS
+	localizers atomic.Pointer[localizerContainer]
+	queryFS    fs.FS
+	fS         fs.FS
 	create     LocalizerCreatorFn
+	base       language.Tag
+	tag        language.Tag
+	collector  MissingCollector
+
+	// pseudo is set when the language is a pseudo-locale, whose messages
+	// are transformed from those of the base language
+	pseudo *pseudoLocale
+}
+
+func newMultiContainer(queryFS, fS fs.FS, create LocalizerCreatorFn,
+	lang *LanguageInfo,
+) *multiContainer {
+	mc := &multiContainer{
+		multiplexor: multiplexor{
+			funcs: TemplateFuncs(lang.Tag),
+		},
+		queryFS:   queryFS,
+		fS:        fS,
+		create:    create,
+		base:      lang.Default,
+		tag:       lang.Tag,
+		collector: lo.Ternary[MissingCollector](lang.Collector != nil, lang.Collector, defaultCollector),
+	}
+	mc.localizers.Store(&localizerContainer{})
+
+	if pseudo, found := pseudoLocales[lang.Tag]; found {
+		mc.pseudo = &pseudo
+	}
+
+	return mc
 }
 
 func (mc *multiContainer) localise(data Localisable) (string, error) {
 	id := data.SourceID()
+	localizer, err := mc.resolve(id)
+
+	if err != nil {
+		return "", err
+	}
+
+	text, tag, err := mc.invoke(localizer, data, mc.message(data))
+
+	// any message presented in a language other than the one requested has
+	// fallen back to the base language of its source, except for that of a
+	// pseudo-locale, which is always derived from the base language.
+	if tag != language.Und && tag != mc.tag && mc.pseudo == nil {
+		mc.collector.Collect(id, data.Message().ID, mc.tag)
+	}
+
+	return text, err
+}
+
+// resolve returns the localizer of the source, which is created on demand,
+// in the base language, if the source has not been declared.
+func (mc *multiContainer) resolve(id string) (*i18n.Localizer, error) {
 	localizer, err := mc.find(id)
 
 	if err != nil {
 		localizer, err = mc.mitigate(id)
 
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
-		mc.add(&LocalizerInfo{
+		// another goroutine may have won the race to add a localizer for
+		// this source, in which case, the winner is used.
+		localizer = mc.add(&LocalizerInfo{
 			Localizer: localizer,
 			SourceID:  id,
 		})
 	}
 
-	return mc.invoke(localizer, data)
+	return localizer, nil
 }
 
-func (mc *multiContainer) add(info *LocalizerInfo) {
-	if _, found := mc.localizers[info.SourceID]; found {
-		return
+// message returns the message of the data, transformed for a pseudo-locale
+func (mc *multiContainer) message(data Localisable) *i18n.Message {
+	if mc.pseudo != nil {
+		return mc.pseudo.message(data.Message())
 	}
 
-	mc.localizers[info.SourceID] = info.Localizer
+	return data.Message()
+}
+
+// add registers the localizer if there is not already one present for the
+// source and returns the localizer that is resident for that source.
+func (mc *multiContainer) add(info *LocalizerInfo) *i18n.Localizer {
+	for {
+		current := mc.localizers.Load()
+
+		if existing, found := (*current)[info.SourceID]; found {
+			return existing
+		}
+
+		next := maps.Clone(*current)
+		next[info.SourceID] = info.Localizer
+
+		if mc.localizers.CompareAndSwap(current, &next) {
+			return info.Localizer
+		}
+	}
+}
+
+// replace registers the localizer for the source, replacing any localizer
+// already present.
+func (mc *multiContainer) replace(info *LocalizerInfo) {
+	for {
+		current := mc.localizers.Load()
+		next := maps.Clone(*current)
+		next[info.SourceID] = info.Localizer
+
+		if mc.localizers.CompareAndSwap(current, &next) {
+			return
+		}
+	}
 }
 
 func (mc *multiContainer) find(id string) (*i18n.Localizer, error) {
-	if loc, found := mc.localizers[id]; found {
+	if loc, found := (*mc.localizers.Load())[id]; found {
 		return loc, nil
 	}
 
 	return nil, NewCouldNotFindLocalizerNativeError(id)
 }
 
+// clone creates a container that initially shares the localizers of this
+// container, but can be added to independently.
+func (mc *multiContainer) clone() *multiContainer {
+	c := &multiContainer{
+		multiplexor: mc.multiplexor,
+		queryFS:     mc.queryFS,
+		fS:          mc.fS,
+		create:      mc.create,
+		base:        mc.base,
+		tag:         mc.tag,
+		collector:   mc.collector,
+		pseudo:      mc.pseudo,
+	}
+	c.localizers.Store(mc.localizers.Load())
+
+	return c
+}
+
 func (mc *multiContainer) mitigate(id string) (*i18n.Localizer, error) {
 	return mc.create(&LanguageInfo{
 		UseOptions: UseOptions{
-			Tag:                 DefaultLanguage,
+			Tag:                 mc.base,
 			DefaultIsAcceptable: true,
 			Create:              mc.create,
 			FS:                  mc.queryFS,
 		},
-		Default: DefaultLanguage,
+		Default: mc.base,
 		Supported: SupportedLanguages{
-			DefaultLanguage,
+			mc.base,
 		},
 	}, id, mc.fS)
 }
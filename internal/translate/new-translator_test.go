@@ -0,0 +1,74 @@
+package translate_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/lab"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+var _ = Describe("NewTranslator", func() {
+	var l10nPath string
+
+	BeforeEach(func() {
+		translate.ResetTx()
+		l10nPath = lab.Repo("test/data/l10n")
+	})
+
+	create := func(tag language.Tag) li18ngo.Translator {
+		tx, err := li18ngo.NewTranslator(func(o *li18ngo.UseOptions) {
+			o.Tag = tag
+			o.From = li18ngo.LoadFrom{
+				Path: l10nPath,
+				Sources: li18ngo.TranslationFiles{
+					li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+				},
+			}
+			o.DefaultIsAcceptable = false
+		})
+		Expect(err).To(Succeed())
+
+		return tx
+	}
+
+	Context("per locale translators", func() {
+		It("🧪 should: localise each translator in its own language", func() {
+			gb := create(language.BritishEnglish)
+			us := create(language.AmericanEnglish)
+
+			Expect(gb.Localise(locale.LocalisationTemplData{})).To(Equal("localisation"))
+			Expect(us.Localise(locale.LocalisationTemplData{})).To(Equal("localization"))
+			Expect(gb.LanguageInfo().Tag).To(Equal(language.BritishEnglish))
+			Expect(us.LanguageInfo().Tag).To(Equal(language.AmericanEnglish))
+		})
+
+		It("🧪 should: not activate the global translator", func() {
+			_ = create(language.AmericanEnglish)
+
+			defer func() {
+				r := recover()
+				err, ok := r.(error)
+				Expect(ok && errors.Is(err, li18ngo.ErrSafePanicWarning)).To(BeTrue())
+			}()
+
+			_ = li18ngo.Text(locale.LocalisationTemplData{})
+			Fail("expected panic to occur")
+		})
+	})
+
+	Context("unsupported language", func() {
+		It("🧪 should: return error when default is not acceptable", func() {
+			_, err := li18ngo.NewTranslator(func(o *li18ngo.UseOptions) {
+				o.Tag = language.Japanese
+				o.DefaultIsAcceptable = false
+			})
+			Expect(err).NotTo(Succeed())
+		})
+	})
+})
@@ -0,0 +1,69 @@
+package translate_test
+
+import (
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/lab"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+// filesFoundTemplData is a message with plural forms, as generated by lingo
+// for a field designated as the PluralCount
+type filesFoundTemplData struct {
+	locale.Li18ngoTemplData
+	Count int
+}
+
+func (td filesFoundTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "files-found.plural.test",
+		Description: "Number of files found",
+		Other:       "{{.Count}} files found",
+		One:         "{{.Count}} file found",
+	}
+}
+
+func (td filesFoundTemplData) PluralCount() any {
+	return td.Count
+}
+
+var _ li18ngo.Countable = filesFoundTemplData{}
+
+var _ = Describe("Plural", func() {
+	BeforeEach(func() {
+		translate.ResetTx()
+	})
+
+	DescribeTable("plural form selection",
+		func(tag language.Tag, count int, expected string) {
+			tx, err := li18ngo.NewTranslator(func(o *li18ngo.UseOptions) {
+				o.Tag = tag
+				o.Supported = li18ngo.SupportedLanguages{language.French}
+				o.DefaultIsAcceptable = false
+				o.From = li18ngo.LoadFrom{
+					Path: lab.Repo("test/data/l10n"),
+					Sources: li18ngo.TranslationFiles{
+						li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+					},
+				}
+			})
+			Expect(err).To(Succeed())
+
+			Expect(tx.Localise(filesFoundTemplData{Count: count})).To(Equal(expected))
+		},
+		func(tag language.Tag, count int, expected string) string {
+			return "🧪 should: select form for " + tag.String() + ": '" + expected + "'"
+		},
+		Entry(nil, language.BritishEnglish, 0, "0 files found"),
+		Entry(nil, language.BritishEnglish, 1, "1 file found"),
+		Entry(nil, language.BritishEnglish, 2, "2 files found"),
+		Entry(nil, language.French, 0, "0 fichier trouvé"),
+		Entry(nil, language.French, 1, "1 fichier trouvé"),
+		Entry(nil, language.French, 2, "2 fichiers trouvés"),
+	)
+})
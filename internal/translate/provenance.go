@@ -0,0 +1,25 @@
+package translate
+
+// Provenance returns the origin of each message the active translator has
+// translated for the source, keyed by message id, reporting the layer (see
+// TranslationSource.Layers) that defined it. This is intended to help an
+// operator determine why a message is presented the way that it is. The
+// origins are determined from the translation files as they currently are,
+// which may differ from the translations in use if the files have changed
+// since they were loaded, unless ReloadOptions.Watch is enabled.
+func Provenance(sourceID string) (map[string]MessageOrigin, error) {
+	if tx := current(); tx != nil {
+		return tx.Provenance(sourceID)
+	}
+
+	return nil, ErrSafePanicWarning
+}
+
+// Provenance returns the origin of each message translated for the source.
+func (t *i18nTranslator) Provenance(sourceID string) (map[string]MessageOrigin, error) {
+	if _, found := t.languageInfo.From.Sources[sourceID]; !found {
+		return nil, NewCouldNotFindLocalizerNativeError(sourceID)
+	}
+
+	return provenance(t.languageInfo, sourceID, t.mx.fS)
+}
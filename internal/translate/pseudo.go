@@ -0,0 +1,196 @@
+package translate
+
+import (
+	"io/fs"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+const (
+	// rlm is the right to left mark
+	rlm = "\u200f"
+
+	// rlo is the right to left override
+	rlo = "\u202e"
+
+	// pdf is the pop directional formatting, which ends an override
+	pdf = "\u202c"
+
+	// pseudoPadding is the rune the text of a pseudo-localised message is
+	// padded with
+	pseudoPadding = "~"
+)
+
+// pseudoLocale describes how the messages of a pseudo-locale are presented.
+// A pseudo-locale has no translation files; the messages are derived from
+// those of the base language of their source.
+type pseudoLocale struct {
+	// accent replaces each letter with an accented equivalent
+	accent bool
+
+	// mirror forces each word to be presented right to left
+	mirror bool
+}
+
+var (
+	// pseudoLocales are the pseudo-locales that may be activated by Use
+	pseudoLocales = map[language.Tag]pseudoLocale{
+		PseudoAccented: {accent: true},
+		PseudoBidi:     {mirror: true},
+	}
+
+	// accents maps each ascii letter to an accented equivalent
+	accents = func() map[rune]rune {
+		plain := []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+		accented := []rune("áƀçðéƒĝĥîĵķļɱñöþǫŕšţûṽŵẋýžÅƁÇÐÉƑĜĤÎĴĶĻṀÑÖÞǪŔŠŢÛṼŴẊÝŽ")
+		m := make(map[rune]rune, len(plain))
+
+		for i, r := range plain {
+			m[r] = accented[i]
+		}
+
+		return m
+	}()
+)
+
+// isPseudo determines whether the tag denotes a pseudo-locale
+func isPseudo(tag language.Tag) bool {
+	_, found := pseudoLocales[tag]
+
+	return found
+}
+
+// pseudoCreator returns the creator of the localizers of a pseudo-locale,
+// which are created for the base language of each source, so that the
+// messages transformed are those the source is authored in. The messages
+// loaded, ie those overridden by a layer, are transformed as they are added
+// to the bundle.
+func pseudoCreator(create LocalizerCreatorFn) LocalizerCreatorFn {
+	return func(li *LanguageInfo, sourceID string, fS fs.FS) (*i18n.Localizer, error) {
+		lang := *li
+		txSource := li.From.Sources[sourceID]
+		pseudo := pseudoLocales[li.Tag]
+		lang.Tag = txSource.base(li)
+		lang.pseudo = &pseudo
+
+		return create(&lang, sourceID, fS)
+	}
+}
+
+// message returns a copy of the message, whose text is transformed. The
+// template actions ({{.Token}}) are preserved, so that the values
+// substituted are presented as is.
+func (p *pseudoLocale) message(m *i18n.Message) *i18n.Message {
+	transformed := *m
+	left, right := delimiters(m)
+
+	for _, form := range []*string{
+		&transformed.Zero, &transformed.One, &transformed.Two,
+		&transformed.Few, &transformed.Many, &transformed.Other,
+	} {
+		if *form != "" {
+			*form = p.transform(*form, left, right)
+		}
+	}
+
+	return &transformed
+}
+
+// transform transforms the literal text between the template actions,
+// padding the result by about 30% and wrapping it in brackets.
+func (p *pseudoLocale) transform(text, left, right string) string {
+	var builder strings.Builder
+
+	builder.WriteString("[")
+	length := 0
+
+	for text != "" {
+		literal, rest, found := strings.Cut(text, left)
+		length += p.literal(&builder, literal)
+
+		if !found {
+			break
+		}
+
+		action, after, closed := strings.Cut(rest, right)
+		if !closed {
+			// a malformed template is left for go-i18n to report
+			builder.WriteString(left + rest)
+
+			break
+		}
+
+		builder.WriteString(left + action + right)
+		text = after
+	}
+
+	if padding := (length*3 + 9) / 10; padding > 0 {
+		builder.WriteString(" " + strings.Repeat(pseudoPadding, padding))
+	}
+
+	builder.WriteString("]")
+
+	return builder.String()
+}
+
+// literal writes the transformed literal text, returning its length in runes
+func (p *pseudoLocale) literal(builder *strings.Builder, text string) int {
+	if p.accent {
+		text = strings.Map(func(r rune) rune {
+			if accented, found := accents[r]; found {
+				return accented
+			}
+
+			return r
+		}, text)
+	}
+
+	if !p.mirror {
+		builder.WriteString(text)
+
+		return utf8.RuneCountInString(text)
+	}
+
+	// each word is overridden separately, so that the spacing between the
+	// words, and any template actions, retain their position.
+	start := -1
+
+	for i, r := range text {
+		switch {
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
+		case unicode.IsSpace(r) && start >= 0:
+			builder.WriteString(rlm + rlo + text[start:i] + pdf + rlm)
+			start = -1
+		}
+
+		if start < 0 {
+			builder.WriteRune(r)
+		}
+	}
+
+	if start >= 0 {
+		builder.WriteString(rlm + rlo + text[start:] + pdf + rlm)
+	}
+
+	return utf8.RuneCountInString(text)
+}
+
+// delimiters returns the delimiters of the template actions of the message
+func delimiters(m *i18n.Message) (left, right string) {
+	left, right = m.LeftDelim, m.RightDelim
+
+	if left == "" {
+		left = "{{"
+	}
+
+	if right == "" {
+		right = "}}"
+	}
+
+	return left, right
+}
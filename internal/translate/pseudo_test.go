@@ -0,0 +1,109 @@
+package translate_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/lab"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+var _ = Describe("Pseudo", func() {
+	var from li18ngo.LoadFrom
+
+	BeforeEach(func() {
+		translate.ResetTx()
+		from = li18ngo.LoadFrom{
+			Path: lab.Repo("test/data/l10n"),
+			Sources: li18ngo.TranslationFiles{
+				li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+			},
+		}
+	})
+
+	When("pseudo-locale is accented", func() {
+		BeforeEach(func() {
+			Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+				o.Tag = li18ngo.PseudoAccented
+				o.DefaultIsAcceptable = false
+				o.From = from
+			})).To(Succeed())
+		})
+
+		It("🧪 should: activate pseudo-locale", func() {
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("[ļöçáļîšáţîöñ ~~~~]"))
+			Expect(li18ngo.Text(locale.NewUsingConfigFileTemplData("jaywalk.yml"))).To(
+				Equal("[Ûšîñĝ çöñƒîĝ ƒîļé: 'jaywalk.yml' ~~~~~~~]"),
+			)
+			Expect(li18ngo.Text(filesFoundTemplData{Count: 1})).To(Equal("[1 ƒîļé ƒöûñð ~~~~]"))
+			Expect(li18ngo.MissingTranslations()).To(BeEmpty())
+		})
+	})
+
+	When("pseudo-locale is bidi", func() {
+		It("🧪 should: mirror each word, except for substitutions", func() {
+			tx, err := li18ngo.NewTranslator(func(o *li18ngo.UseOptions) {
+				o.Tag = li18ngo.PseudoBidi
+				o.From = from
+			})
+			Expect(err).To(Succeed())
+			Expect(tx.LanguageInfo().Tag).To(Equal(li18ngo.PseudoBidi))
+
+			rtl := func(word string) string {
+				return "\u200f\u202e" + word + "\u202c\u200f"
+			}
+			Expect(tx.Localise(locale.NewUsingConfigFileTemplData("jaywalk.yml"))).To(Equal(
+				"[" + rtl("Using") + " " + rtl("config") + " " + rtl("file:") + " " +
+					rtl("'") + "jaywalk.yml" + rtl("'") + " ~~~~~~~]",
+			))
+		})
+	})
+
+	When("layer overrides the base language", func() {
+		It("🧪 should: transform overridden message", func() {
+			sitePath := GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(sitePath, "test.active.en-GB.json"), []byte(`{
+  "localisation.test": {
+    "description": "Localisation",
+    "other": "localisation (site)"
+  },
+  "using-config-file": {
+    "description": "Message to indicate which config is being used",
+    "other": "Site config file: {{.ConfigFileName}}"
+  }
+}`), 0o600)).To(Succeed())
+
+			source := from.Sources[li18ngo.Li18ngoSourceID]
+			source.Layers = []li18ngo.Layer{{Name: "site", Path: sitePath}}
+			from.Sources[li18ngo.Li18ngoSourceID] = source
+
+			tx, err := li18ngo.NewTranslator(func(o *li18ngo.UseOptions) {
+				o.Tag = li18ngo.PseudoAccented
+				o.From = from
+			})
+			Expect(err).To(Succeed())
+
+			Expect(tx.Localise(locale.LocalisationTemplData{})).To(Equal("[ļöçáļîšáţîöñ (šîţé) ~~~~~~]"))
+			Expect(tx.Localise(locale.NewUsingConfigFileTemplData("jaywalk.yml"))).To(
+				Equal("[Šîţé çöñƒîĝ ƒîļé: jaywalk.yml ~~~~~~]"),
+			)
+		})
+	})
+
+	When("pseudo-locale is requested via context", func() {
+		It("🧪 should: derive pseudo-locale translator", func() {
+			Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+				o.From = from
+			})).To(Succeed())
+
+			ctx := li18ngo.WithLanguage(context.Background(), li18ngo.PseudoAccented)
+			Expect(li18ngo.TextCtx(ctx, locale.LocalisationTemplData{})).To(Equal("[ļöçáļîšáţîöñ ~~~~]"))
+		})
+	})
+})
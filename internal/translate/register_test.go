@@ -1,14 +1,78 @@
 package translate_test
 
 import (
+	"testing/fstest"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
 
 	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/lab"
 	"github.com/snivilised/li18ngo/internal/translate"
 	"github.com/snivilised/li18ngo/locale"
 )
 
+const librarySourceID = "github.com/snivilised/library"
+
+// colourTemplData is a message defined by a library that ships its own
+// translations
+type colourTemplData struct{}
+
+func (td colourTemplData) SourceID() string {
+	return librarySourceID
+}
+
+func (td colourTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "colour.library.test",
+		Description: "Colour",
+		Other:       "colour",
+	}
+}
+
+// libraryFS simulates the translations embedded in the library
+var libraryFS = fstest.MapFS{
+	"l10n/library.active.en-US.json": &fstest.MapFile{
+		Data: []byte(`{
+  "colour.library.test": {
+    "description": "Colour",
+    "hash": "sha1-6ca1f236012b99c5b4ed8b9310322d85824c33ca",
+    "other": "color"
+  }
+}`),
+	},
+	"l10n/library.active.ja.json": &fstest.MapFile{
+		Data: []byte(`{
+  "colour.library.test": {
+    "description": "Colour",
+    "hash": "sha1-6ca1f236012b99c5b4ed8b9310322d85824c33ca",
+    "other": "色"
+  }
+}`),
+	},
+}
+
+func registerLibrary(o *li18ngo.RegisterOptions) {
+	o.SourceID = librarySourceID
+	o.Name = "library"
+	o.Path = "l10n"
+	o.DefaultFS = libraryFS
+	o.Supported = li18ngo.SupportedLanguages{language.AmericanEnglish}
+}
+
+func useAmericanEnglish(o *li18ngo.UseOptions) {
+	o.Tag = language.AmericanEnglish
+	o.DefaultIsAcceptable = false
+	o.From = li18ngo.LoadFrom{
+		Path: lab.Repo("test/data/l10n"),
+		Sources: li18ngo.TranslationFiles{
+			li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+		},
+	}
+}
+
 var _ = Describe("Register", func() {
 	BeforeEach(func() {
 		translate.ResetTx()
@@ -57,4 +121,59 @@ var _ = Describe("Register", func() {
 			})
 		})
 	})
+
+	Context("library translations", func() {
+		When("Register is called before Use", func() {
+			It("🧪 should: translate from the library's file system", func() {
+				Expect(li18ngo.Register(registerLibrary)).To(Succeed())
+				Expect(li18ngo.Use(useAmericanEnglish)).To(Succeed())
+
+				Expect(li18ngo.Text(colourTemplData{})).To(Equal("color"))
+				Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localization"),
+					"implicit translator activated by Register should be replaced by Use",
+				)
+			})
+		})
+
+		When("Use is called before Register", func() {
+			It("🧪 should: translate from the library's file system", func() {
+				Expect(li18ngo.Use(useAmericanEnglish)).To(Succeed())
+				Expect(li18ngo.Text(colourTemplData{})).To(Equal("colour"),
+					"should present base language prior to registration",
+				)
+
+				Expect(li18ngo.Register(registerLibrary)).To(Succeed())
+				Expect(li18ngo.Text(colourTemplData{})).To(Equal("color"))
+			})
+		})
+
+		When("host declares the library's source", func() {
+			It("🧪 should: give precedence to the host", func() {
+				Expect(li18ngo.Register(registerLibrary)).To(Succeed())
+				Expect(li18ngo.Use(useAmericanEnglish, func(o *li18ngo.UseOptions) {
+					o.From.Sources[librarySourceID] = li18ngo.TranslationSource{
+						Name: "test.library",
+					}
+				})).To(Succeed())
+
+				Expect(li18ngo.Text(colourTemplData{})).To(Equal("color (host)"))
+			})
+		})
+
+		When("library supports a language the host does not", func() {
+			It("🧪 should: include the library's languages", func() {
+				Expect(li18ngo.Register(func(o *li18ngo.RegisterOptions) {
+					registerLibrary(o)
+					o.Supported = li18ngo.SupportedLanguages{language.Japanese}
+				})).To(Succeed())
+
+				Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+					o.Tag = language.Japanese
+				})).To(Succeed())
+				Expect(li18ngo.Text(colourTemplData{})).To(Equal("色"),
+					"Japanese should not be substituted by the default language",
+				)
+			})
+		})
+	})
 })
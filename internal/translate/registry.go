@@ -0,0 +1,148 @@
+package translate
+
+import (
+	"sync"
+)
+
+var (
+	// registry holds the sources registered by libraries via Register
+	registry = make(TranslationFiles)
+
+	// registration guards registry
+	registration sync.Mutex
+
+	// implicit indicates that the active translator was activated by
+	// Register, because the host had not yet invoked Use. Guarded by
+	// activation.
+	implicit bool
+)
+
+// Register is the library-tier equivalent of Use. A library that depends on
+// li18ngo should call Register to declare its own translation files, which
+// are then available to the host application regardless of whether the
+// host invokes Use before or after the library registers. Libraries must
+// never call Use - that is an application bootstrap concern.
+//
+// If no translator is active, Register activates one in the default
+// language, so that Text can be invoked; this translator is replaced when
+// the host invokes Use. A source that the host declares in its call to Use
+// takes precedence over the one registered by the library, allowing the host
+// to provide its own translations for the library.
+func Register(options ...RegisterOptionFn) error {
+	o := &RegisterOptions{}
+
+	for _, fo := range options {
+		fo(o)
+	}
+
+	activation.Lock()
+	defer activation.Unlock()
+
+	if o.SourceID != "" {
+		registration.Lock()
+		registry[o.SourceID] = o.source()
+		registration.Unlock()
+	}
+
+	tx := current()
+
+	if tx == nil {
+		lang, err := newLanguage()
+		if err != nil {
+			return err
+		}
+
+		created, err := createTranslator(lang, nil)
+		if err != nil {
+			return err
+		}
+
+		activate(created)
+		implicit = true
+
+		return nil
+	}
+
+	if o.SourceID == "" {
+		return nil
+	}
+
+	t, ok := tx.(*i18nTranslator)
+	if !ok {
+		return ErrInvalidTranslator
+	}
+
+	registered, err := t.register(o.SourceID, o.source())
+	if err != nil {
+		return err
+	}
+
+	activate(registered)
+	rewatch(registered)
+
+	return nil
+}
+
+// source returns the translation source described by the options
+func (o *RegisterOptions) source() TranslationSource {
+	return TranslationSource{
+		Name:      o.Name,
+		Path:      o.Path,
+		Base:      o.Base,
+		Supported: o.Supported,
+		FS:        o.DefaultFS,
+	}
+}
+
+// includeRegistered adds the sources registered by libraries to those of the
+// language, except for those already declared, indicating whether any were
+// added.
+func includeRegistered(lang *LanguageInfo) bool {
+	registration.Lock()
+	defer registration.Unlock()
+
+	included := false
+
+	for id, source := range registry {
+		if _, found := lang.From.Sources[id]; !found {
+			lang.From.Sources[id] = source
+			included = true
+		}
+	}
+
+	return included
+}
+
+func resetRegistry() {
+	registration.Lock()
+	defer registration.Unlock()
+
+	clear(registry)
+}
+
+// register returns a translator that combines the sources of this translator
+// with the source registered. A source already declared is retained, so that
+// the host's declaration takes precedence over that of the library.
+func (t *i18nTranslator) register(sourceID string, source TranslationSource) (Translator, error) {
+	if _, found := t.languageInfo.From.Sources[sourceID]; found {
+		return t, nil
+	}
+
+	registered := t.clone()
+	registered.languageInfo.From.Sources[sourceID] = source
+	registered.languageInfo.Supported = effectiveLanguages(registered.languageInfo)
+
+	localizer, err := registered.mx.create(registered.languageInfo, sourceID, registered.mx.fS)
+	if err != nil {
+		return nil, err
+	}
+
+	// a localizer may already have been created on the fly for the source's
+	// messages, before it was registered, which is now superseded.
+	registered.mx.replace(&LocalizerInfo{
+		Localizer: localizer,
+		SourceID:  sourceID,
+	})
+
+	return registered, nil
+}
@@ -0,0 +1,252 @@
+package translate
+
+import (
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// defaultPollInterval is the interval at which translation files are
+	// polled for changes, when not specified by ReloadOptions.
+	defaultPollInterval = time.Second * 2
+
+	// settleInterval is the time allowed for a burst of change notifications,
+	// as typically raised when a file is saved, to settle before reloading.
+	settleInterval = time.Millisecond * 100
+)
+
+// watching is the watcher of the active translator's translation files. It
+// is guarded by activation.
+var watching *watcher
+
+// Reload recreates the localizers of the active translator from their
+// translation files. This is the manual equivalent of ReloadOptions.Watch.
+// A source whose translations can not be loaded continues with its
+// existing localizer and the error is returned.
+func Reload() error {
+	if tx := current(); tx != nil {
+		return tx.Reload()
+	}
+
+	return ErrSafePanicWarning
+}
+
+// rewatch replaces the watcher of the previously active translator with one
+// for the translator just activated, if it has requested watching. Must be
+// invoked with activation held.
+func rewatch(tx Translator) {
+	if watching != nil {
+		watching.stop()
+		watching = nil
+	}
+
+	if t, ok := tx.(*i18nTranslator); ok && t.languageInfo.Reload.Watch {
+		watching = newWatcher(t)
+	}
+}
+
+// fileState is the state of a translation file, used to detect a change when
+// polling.
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+// watchedFile is a translation file of the watched translator
+type watchedFile struct {
+	// fS is the file system the file is loaded from
+	fS fs.FS
+
+	// ids of the sources that consult the file
+	ids []string
+}
+
+// watcher reloads the localizers of a translator whose translation files
+// change.
+type watcher struct {
+	tx *i18nTranslator
+
+	// files maps the path of each translation file to the file
+	files map[string]*watchedFile
+
+	quit chan struct{}
+	done chan struct{}
+}
+
+func newWatcher(t *i18nTranslator) *watcher {
+	w := &watcher{
+		tx:    t,
+		files: make(map[string]*watchedFile),
+		quit:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+
+	notifiable := true
+
+	for id := range t.languageInfo.From.Sources {
+		for _, file := range bundleFiles(t.languageInfo, id, t.mx.fS) {
+			if file.fixed {
+				continue
+			}
+
+			if _, found := w.files[file.path]; !found {
+				w.files[file.path] = &watchedFile{fS: file.fS}
+			}
+
+			w.files[file.path].ids = append(w.files[file.path].ids, id)
+			notifiable = notifiable && (file.layer > 0 || t.languageInfo.FS == nil)
+		}
+	}
+
+	// change notifications are only available for the host file system,
+	// otherwise and in the event that notifications can not be set up, the
+	// files are polled.
+	if notifiable {
+		if notifier, err := w.notifier(); err == nil {
+			go w.notify(notifier)
+
+			return w
+		}
+	}
+
+	// the initial state is captured before returning, so that any change
+	// made after activation is detected.
+	go w.poll(t.languageInfo.Reload.Interval, w.inspect())
+
+	return w
+}
+
+func (w *watcher) stop() {
+	close(w.quit)
+	<-w.done
+}
+
+// notifier creates a notifier for the directories containing the translation
+// files; the directories are watched rather than the files themselves, so
+// that files replaced by an editor on save continue to be watched.
+func (w *watcher) notifier() (*fsnotify.Watcher, error) {
+	notifier, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	directories := make(map[string]struct{})
+	for path := range w.files {
+		directories[filepath.Dir(path)] = struct{}{}
+	}
+
+	for directory := range directories {
+		if err := notifier.Add(directory); err != nil {
+			_ = notifier.Close()
+			return nil, err
+		}
+	}
+
+	return notifier, nil
+}
+
+// notify reloads the sources whose files have changed according to change
+// notifications. The notifications are allowed to settle, so that a burst of
+// notifications results in a single reload.
+func (w *watcher) notify(notifier *fsnotify.Watcher) {
+	defer close(w.done)
+	defer notifier.Close() //nolint:errcheck // nothing to do on failure
+
+	settle := time.NewTimer(settleInterval)
+	settle.Stop()
+
+	pending := make(map[string]struct{})
+
+	for {
+		select {
+		case <-w.quit:
+			settle.Stop()
+			return
+
+		case event, ok := <-notifier.Events:
+			if !ok {
+				return
+			}
+
+			if file, found := w.files[filepath.Clean(event.Name)]; found {
+				for _, id := range file.ids {
+					pending[id] = struct{}{}
+				}
+			}
+
+			if len(pending) > 0 {
+				settle.Reset(settleInterval)
+			}
+
+		case <-notifier.Errors:
+			// errors are not actionable, changes are still notified
+
+		case <-settle.C:
+			_ = w.tx.reload(slices.Collect(maps.Keys(pending)))
+			clear(pending)
+		}
+	}
+}
+
+// poll reloads the sources whose files have changed according to their state
+// in the file system, inspected at the interval specified.
+func (w *watcher) poll(interval time.Duration, states map[string]fileState) {
+	defer close(w.done)
+
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.quit:
+			return
+
+		case <-ticker.C:
+			latest := w.inspect()
+			changed := make(map[string]struct{})
+
+			for path, state := range latest {
+				if state != states[path] {
+					for _, id := range w.files[path].ids {
+						changed[id] = struct{}{}
+					}
+				}
+			}
+
+			states = latest
+
+			if len(changed) > 0 {
+				_ = w.tx.reload(slices.Collect(maps.Keys(changed)))
+			}
+		}
+	}
+}
+
+// inspect captures the state of each translation file
+func (w *watcher) inspect() map[string]fileState {
+	states := make(map[string]fileState, len(w.files))
+
+	for path, file := range w.files {
+		if info, err := fs.Stat(file.fS, path); err == nil {
+			states[path] = fileState{
+				exists:  true,
+				size:    info.Size(),
+				modTime: info.ModTime(),
+			}
+		} else {
+			states[path] = fileState{}
+		}
+	}
+
+	return states
+}
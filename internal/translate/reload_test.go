@@ -0,0 +1,131 @@
+package translate_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	nef "github.com/snivilised/nefilim"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+const (
+	reloadFrenchV1 = `{
+  "localisation.test": {
+    "description": "Localisation",
+    "hash": "sha1-053e15971b8d428c47cdb902f90c4fcecc72e253",
+    "other": "localisation (v1)"
+  }
+}`
+
+	reloadFrenchV2 = `{
+  "localisation.test": {
+    "description": "Localisation",
+    "hash": "sha1-053e15971b8d428c47cdb902f90c4fcecc72e253",
+    "other": "localisation (v2)"
+  }
+}`
+)
+
+var _ = Describe("Reload", Ordered, func() {
+	var (
+		l10nPath string
+		filePath string
+	)
+
+	use := func(options ...li18ngo.UseOptionFn) {
+		Expect(li18ngo.Use(append([]li18ngo.UseOptionFn{
+			func(o *li18ngo.UseOptions) {
+				o.Tag = language.French
+				o.Supported = li18ngo.SupportedLanguages{language.French}
+				o.From = li18ngo.LoadFrom{
+					Path: l10nPath,
+					Sources: li18ngo.TranslationFiles{
+						li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+					},
+				}
+			},
+		}, options...)...)).To(Succeed())
+	}
+
+	write := func(content string) {
+		Expect(os.WriteFile(filePath, []byte(content), 0o600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		translate.ResetTx()
+		l10nPath = GinkgoT().TempDir()
+		filePath = filepath.Join(l10nPath, "test.active.fr.json")
+		write(reloadFrenchV1)
+	})
+
+	AfterEach(func() {
+		translate.ResetTx()
+	})
+
+	Context("manual", func() {
+		It("🧪 should: present the reloaded translation", func() {
+			use()
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localisation (v1)"))
+
+			write(reloadFrenchV2)
+			Expect(li18ngo.Reload()).To(Succeed())
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localisation (v2)"))
+		})
+
+		When("translation file is malformed", func() {
+			It("🧪 should: retain the existing translation", func() {
+				var failed []string
+
+				use(func(o *li18ngo.UseOptions) {
+					o.Reload.OnFailed = func(sourceID string, _ error) {
+						failed = append(failed, sourceID)
+					}
+				})
+
+				write("{ malformed")
+				Expect(li18ngo.Reload()).NotTo(Succeed())
+				Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localisation (v1)"))
+				Expect(failed).To(ConsistOf(li18ngo.Li18ngoSourceID))
+			})
+		})
+	})
+
+	Context("watch", func() {
+		When("host file system is notified", func() {
+			It("🧪 should: present the reloaded translation", func() {
+				use(func(o *li18ngo.UseOptions) {
+					o.Reload.Watch = true
+				})
+
+				write(reloadFrenchV2)
+				Eventually(func() string {
+					return li18ngo.Text(locale.LocalisationTemplData{})
+				}).WithTimeout(time.Second * 5).Should(Equal("localisation (v2)"))
+			})
+		})
+
+		When("file system is polled", func() {
+			It("🧪 should: present the reloaded translation", func() {
+				use(func(o *li18ngo.UseOptions) {
+					o.FS = nef.NewReaderABS()
+					o.Reload.Watch = true
+					o.Reload.Interval = time.Millisecond * 10
+				})
+
+				// ensure the modification is observable, even on file systems
+				// with coarse timestamps, by changing the size of the file.
+				write(reloadFrenchV2 + "\n")
+				Eventually(func() string {
+					return li18ngo.Text(locale.LocalisationTemplData{})
+				}).WithTimeout(time.Second * 5).Should(Equal("localisation (v2)"))
+			})
+		})
+	})
+})
@@ -0,0 +1,102 @@
+package translate_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/lab"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+const (
+	expectFR = "Fichier de configuration utilisé : 'jaywalk.yml'"
+	expectGB = "Using config file: 'jaywalk.yml'"
+)
+
+var _ = Describe("Supported", func() {
+	var l10nPath string
+
+	BeforeEach(func() {
+		translate.ResetTx()
+		l10nPath = lab.Repo("test/data/l10n")
+	})
+
+	Context("client declares supported languages", func() {
+		When("declared on UseOptions", func() {
+			It("🧪 should: translate into the declared language", func() {
+				Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+					o.Tag = language.French
+					o.Supported = li18ngo.SupportedLanguages{language.French}
+					o.DefaultIsAcceptable = false
+					o.From = li18ngo.LoadFrom{
+						Path: l10nPath,
+						Sources: li18ngo.TranslationFiles{
+							li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+						},
+					}
+				})).To(Succeed())
+
+				Expect(li18ngo.Text(locale.NewUsingConfigFileTemplData("jaywalk.yml"))).To(
+					Equal(expectFR),
+				)
+			})
+		})
+
+		When("declared on a TranslationSource", func() {
+			It("🧪 should: include the source languages in the effective set", func() {
+				tx, err := li18ngo.NewTranslator(func(o *li18ngo.UseOptions) {
+					o.Tag = language.French
+					o.DefaultIsAcceptable = false
+					o.From = li18ngo.LoadFrom{
+						Path: l10nPath,
+						Sources: li18ngo.TranslationFiles{
+							li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{
+								Name:      "test",
+								Supported: li18ngo.SupportedLanguages{language.French},
+							},
+						},
+					}
+				})
+				Expect(err).To(Succeed())
+				Expect(tx.LanguageInfo().Supported).To(ConsistOf(
+					language.BritishEnglish, language.AmericanEnglish, language.French,
+				))
+				Expect(tx.Localise(locale.NewUsingConfigFileTemplData("jaywalk.yml"))).To(
+					Equal(expectFR),
+				)
+			})
+		})
+	})
+
+	Context("language not declared", func() {
+		When("default is acceptable", func() {
+			It("🧪 should: fall back to the default language", func() {
+				Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+					o.Tag = language.French
+					o.From = li18ngo.LoadFrom{
+						Path: l10nPath,
+						Sources: li18ngo.TranslationFiles{
+							li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+						},
+					}
+				})).To(Succeed())
+
+				Expect(li18ngo.Text(locale.NewUsingConfigFileTemplData("jaywalk.yml"))).To(
+					Equal(expectGB),
+				)
+			})
+		})
+
+		When("default is not acceptable", func() {
+			It("🧪 should: return error", func() {
+				Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+					o.Tag = language.French
+					o.DefaultIsAcceptable = false
+				})).NotTo(Succeed())
+			})
+		})
+	})
+})
@@ -0,0 +1,111 @@
+package translate
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+var (
+	// subscribers are notified when the language is switched
+	subscribers = make(map[int]LanguageChangedFn)
+
+	// subscription guards subscribers
+	subscription sync.Mutex
+
+	// nextSubscriber is the id allocated to the next subscriber
+	nextSubscriber int
+)
+
+// SwitchLanguage switches the active translator to the language specified.
+// Unlike Use, which only adds new sources to the active translator, the
+// localizers of every source known to the active translator, including those
+// added via Register, are recreated in the new language. Once the new
+// translator has been activated, subscribers registered with
+// OnLanguageChanged are notified, so that content can be re-rendered.
+func SwitchLanguage(tag language.Tag) error {
+	switched, err := switchLanguage(tag)
+	if err != nil {
+		return err
+	}
+
+	if switched {
+		notify(tag)
+	}
+
+	return nil
+}
+
+// switchLanguage activates a translator for the language specified,
+// indicating whether the language has changed.
+func switchLanguage(tag language.Tag) (bool, error) {
+	activation.Lock()
+	defer activation.Unlock()
+
+	tx := current()
+	if tx == nil {
+		return false, ErrSafePanicWarning
+	}
+
+	if tx.LanguageInfo().Tag == tag {
+		return false, nil
+	}
+
+	if !containsLanguage(tx.LanguageInfo().Supported, tag) && !isPseudo(tag) {
+		return false, NewFailedToCreateTranslatorNativeError(tag)
+	}
+
+	lang := *tx.LanguageInfo()
+	lang.Tag = tag
+	lang.From.Sources = maps.Clone(lang.From.Sources)
+
+	switched, err := createTranslator(&lang, nil)
+	if err != nil {
+		return false, err
+	}
+
+	activate(switched)
+	rewatch(switched)
+
+	return true, nil
+}
+
+// OnLanguageChanged registers a function that is invoked whenever the
+// language is switched via SwitchLanguage. The function is invoked on the
+// goroutine that switched the language, after the new translator has been
+// activated, so Text presents content in the new language. The function
+// returned unsubscribes.
+func OnLanguageChanged(fn LanguageChangedFn) (unsubscribe func()) {
+	subscription.Lock()
+	defer subscription.Unlock()
+
+	id := nextSubscriber
+	nextSubscriber++
+	subscribers[id] = fn
+
+	return func() {
+		subscription.Lock()
+		defer subscription.Unlock()
+
+		delete(subscribers, id)
+	}
+}
+
+// notify invokes the subscribers without holding the lock, so that a
+// subscriber is free to subscribe, unsubscribe or switch language.
+func notify(tag language.Tag) {
+	subscription.Lock()
+	fns := make([]LanguageChangedFn, 0, len(subscribers))
+
+	// subscribers are notified in the order they subscribed
+	for _, id := range slices.Sorted(maps.Keys(subscribers)) {
+		fns = append(fns, subscribers[id])
+	}
+	subscription.Unlock()
+
+	for _, fn := range fns {
+		fn(tag)
+	}
+}
@@ -0,0 +1,97 @@
+package translate_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/language"
+
+	"github.com/snivilised/li18ngo"
+	"github.com/snivilised/li18ngo/internal/lab"
+	"github.com/snivilised/li18ngo/internal/translate"
+	"github.com/snivilised/li18ngo/locale"
+)
+
+var _ = Describe("SwitchLanguage", func() {
+	var l10nPath string
+
+	BeforeEach(func() {
+		translate.ResetTx()
+		l10nPath = lab.Repo("test/data/l10n")
+	})
+
+	When("translator is active", func() {
+		BeforeEach(func() {
+			Expect(li18ngo.Use(func(o *li18ngo.UseOptions) {
+				o.Tag = language.BritishEnglish
+				o.From = li18ngo.LoadFrom{
+					Path: l10nPath,
+					Sources: li18ngo.TranslationFiles{
+						li18ngo.Li18ngoSourceID: li18ngo.TranslationSource{Name: "test"},
+					},
+				}
+			})).To(Succeed())
+
+			Expect(li18ngo.Register(func(o *li18ngo.RegisterOptions) {
+				o.SourceID = deutschSourceID
+				o.Name = "test.deutsch"
+				o.DefaultFS = os.DirFS(l10nPath)
+				o.Base = language.German
+			})).To(Succeed())
+		})
+
+		It("🧪 should: translate all sources in the new language", func() {
+			Expect(li18ngo.SwitchLanguage(language.AmericanEnglish)).To(Succeed())
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localization"))
+			Expect(li18ngo.Text(tramTemplData{})).To(Equal("Straßenbahn"),
+				"registered source without en-US translation should present its base language",
+			)
+
+			Expect(li18ngo.SwitchLanguage(language.BritishEnglish)).To(Succeed())
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localisation"))
+			Expect(li18ngo.Text(tramTemplData{})).To(Equal("tram"))
+		})
+
+		It("🧪 should: notify subscribers until unsubscribed", func() {
+			var notified []language.Tag
+
+			unsubscribe := li18ngo.OnLanguageChanged(func(tag language.Tag) {
+				Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localization"),
+					"new language should be active when notified",
+				)
+				notified = append(notified, tag)
+			})
+
+			Expect(li18ngo.SwitchLanguage(language.AmericanEnglish)).To(Succeed())
+			Expect(li18ngo.SwitchLanguage(language.AmericanEnglish)).To(Succeed())
+			unsubscribe()
+			Expect(li18ngo.SwitchLanguage(language.BritishEnglish)).To(Succeed())
+
+			Expect(notified).To(Equal([]language.Tag{language.AmericanEnglish}),
+				"should only be notified of an actual change, whilst subscribed",
+			)
+		})
+
+		It("🧪 should: switch to pseudo-locale", func() {
+			Expect(li18ngo.SwitchLanguage(li18ngo.PseudoAccented)).To(Succeed())
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("[ļöçáļîšáţîöñ ~~~~]"))
+
+			Expect(li18ngo.SwitchLanguage(language.BritishEnglish)).To(Succeed())
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localisation"))
+		})
+
+		It("🧪 should: reject unsupported language", func() {
+			Expect(li18ngo.SwitchLanguage(language.Japanese)).NotTo(Succeed())
+			Expect(li18ngo.Text(locale.LocalisationTemplData{})).To(Equal("localisation"))
+		})
+	})
+
+	When("translator is not active", func() {
+		It("🧪 should: return error", func() {
+			Expect(li18ngo.SwitchLanguage(language.AmericanEnglish)).To(
+				MatchError(li18ngo.ErrSafePanicWarning),
+			)
+		})
+	})
+})
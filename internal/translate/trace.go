@@ -0,0 +1,35 @@
+package translate
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// tracef writes a line to the trace, if it has been enabled by the TraceEnv
+// environment variable. The variable is consulted on every invocation, so
+// that tracing can be enabled without restarting; tracing is confined to the
+// creation of localizers, so this is not on the path of every message.
+func tracef(format string, args ...any) {
+	target := os.Getenv(TraceEnv)
+	if target == "" {
+		return
+	}
+
+	line := fmt.Sprintf("li18ngo: "+format+"\n", args...)
+
+	switch strings.ToLower(target) {
+	case "1", "true", "stderr":
+		_, _ = os.Stderr.WriteString(line)
+
+		return
+	}
+
+	file, err := os.OpenFile(target, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	_, _ = file.WriteString(line)
+}
@@ -1,9 +1,14 @@
 package translate
 
 import (
+	"io/fs"
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/pkg/errors"
-	nef "github.com/snivilised/nefilim"
+	"github.com/snivilised/li18ngo/internal/third/lo"
 	"golang.org/x/text/language"
 )
 
@@ -16,6 +21,34 @@ const (
 	// correct i18n.Localizer (identified by the SourceID). The Source is
 	// statically defined for all templates defined in li18ngo.
 	Li18ngoSourceID = "github.com/snivilised/li18ngo"
+
+	// FormatJSON denotes translation files in json; <name>.active.<tag>.json
+	FormatJSON Format = "json"
+
+	// FormatYAML denotes translation files in yaml; <name>.active.<tag>.yaml
+	FormatYAML Format = "yaml"
+
+	// FormatTOML denotes translation files in toml; <name>.active.<tag>.toml
+	FormatTOML Format = "toml"
+
+	// ResolutionDeclared denotes a localizer created for a source declared
+	// by Use or Register
+	ResolutionDeclared Resolution = "declared"
+
+	// ResolutionOnDemand denotes a localizer created on demand, in the base
+	// language, for a source that has not been declared
+	ResolutionOnDemand Resolution = "on-demand"
+
+	// ResolutionNone denotes that there is no active translator, so the
+	// message is presented as defined in the source
+	ResolutionNone Resolution = "none"
+
+	// TraceEnv is the environment variable that enables the trace of the
+	// translation files resolved and loaded whenever a localizer is
+	// created. A value of "1", "true" or "stderr" writes the trace to
+	// stderr, any other value is the path of a file the trace is appended
+	// to.
+	TraceEnv = "LI18NGO_TRACE"
 )
 
 var (
@@ -29,6 +62,10 @@ type (
 	// SupportedLanguages is a list of supported languages for this package.
 	SupportedLanguages []language.Tag
 
+	// Format is the format of a translation file, which is also the file's
+	// extension.
+	Format string
+
 	// Localisable represents the data required to localise a message.
 	Localisable interface {
 		// Message returns the i18n.Message to be localised.
@@ -44,6 +81,16 @@ type (
 		SourceID() string
 	}
 
+	// Countable is optionally implemented by Localisable data for messages that
+	// have plural forms. lingo generates it for template data that has a field
+	// designated as the PluralCount.
+	Countable interface {
+		// PluralCount returns the count that selects the plural form of the
+		// message. It may be an integer, a float or a string representation of
+		// a number.
+		PluralCount() any
+	}
+
 	TranslationSource struct {
 		// Name of dependency's translation file
 		Name string
@@ -53,11 +100,115 @@ type (
 		// If not specified, then a search will be performed in the current working
 		// directory for the translation file.
 		Path string
+
+		// Base is the language the source's messages are authored in, ie the
+		// language of the Other strings defined in its templates. If not
+		// specified, the Base language of UseOptions applies.
+		Base language.Tag
+
+		// Supported denotes the languages for which this source provides
+		// translations. Translations are not loaded for a language that is not
+		// listed, unless Supported is empty, in which case a load is always
+		// attempted.
+		Supported SupportedLanguages
+
+		// FS when set, is the file system the source's translation files are
+		// loaded from, in which case Path is the directory within FS. This is
+		// how a library provides its own (typically embedded) translations,
+		// see RegisterOptions.
+		FS fs.FS
+
+		// Format of the source's translation files. If not specified, the
+		// Format of UseOptions applies.
+		Format Format
+
+		// Layers are stacked on top of the source's own translations, so that
+		// an operator can override individual messages without rebuilding, eg
+		// a site wide layer in /etc/myapp/l10n, followed by a user layer in
+		// $XDG_CONFIG_HOME/myapp/l10n. A message defined by a later layer
+		// overrides that of an earlier layer and the source's own translations.
+		Layers []Layer
+	}
+
+	// Layer is a directory of translation files that override those of a
+	// source. A layer contains files with the same name as the source's own,
+	// but only needs to define the messages it overrides; a layer that does
+	// not contain a file for a language is ignored.
+	Layer struct {
+		// Name identifies the layer, eg "site" or "user", see MessageOrigin
+		Name string
+
+		// Path is the directory containing the layer's translation files
+		Path string
+
+		// FS when set, is the file system the layer's files are loaded from,
+		// in which case Path is the directory within FS. When not set, the
+		// layer is loaded from the host file system, even when translations
+		// are otherwise loaded from UseOptions.FS.
+		FS fs.FS
+	}
+
+	// MessageOrigin identifies the translation file a message was loaded from.
+	MessageOrigin struct {
+		// Layer is the name of the layer that defined the message, which is
+		// empty for the source's own translations
+		Layer string
+
+		// Tag is the language of the file that defined the message, which may
+		// be a fallback of the language requested
+		Tag language.Tag
+
+		// Path of the file that defined the message
+		Path string
+	}
+
+	// Resolution denotes how the localizer of a message was resolved
+	Resolution string
+
+	// Explanation describes how a message was localised, see Explain.
+	Explanation struct {
+		// SourceID is the id of the source the message belongs to
+		SourceID string
+
+		// MessageID is the id of the message
+		MessageID string
+
+		// Localizer denotes how the localizer of the source was resolved
+		Localizer Resolution
+
+		// Requested is the language requested, ie that of the translator
+		Requested language.Tag
+
+		// Tag is the language actually matched, which may be a fallback of
+		// the language requested or the base language of the source
+		Tag language.Tag
+
+		// Path of the translation file that defined the message, which is
+		// empty when the default message was used
+		Path string
+
+		// Layer is the name of the layer that defined the message, which is
+		// empty for the source's own translations
+		Layer string
+
+		// Default denotes that the message was presented as defined in the
+		// source, because no translation file defined it
+		Default bool
+
+		// Text is the localised message
+		Text string
+
+		// Err is the error that occurred localising the message, if any
+		Err error
 	}
 
 	// TranslationFiles maps a source id to a TranslationSource
 	TranslationFiles map[string]TranslationSource
 
+	// Availability maps a source id to the languages for which translation
+	// files were discovered.
+	Availability map[string]SupportedLanguages
+
 	// LoadFrom denotes where to load the translation file from
 	LoadFrom struct {
 		// Path denoting where to load language file from, defaults to exe location
@@ -78,20 +229,177 @@ type (
 	// LocalizerCreatorFn represents the signature of the function that can
 	// optionally be provided to override how an i18n Localizer is created.
 	LocalizerCreatorFn func(li *LanguageInfo, sourceID string,
-		fS nef.ReaderFS,
+		fS fs.FS,
 	) (*i18n.Localizer, error)
 
+	// DetectorFn resolves the language to use from the languages supported,
+	// returning the confidence of the match. A confidence of language.No
+	// indicates that no language could be detected.
+	DetectorFn func(supported SupportedLanguages) (language.Tag, language.Confidence)
+
+	// ErrorHandlerFn is invoked when a message can not be localised. It
+	// receives the message data, the id of the source the message belongs
+	// to, the language requested and the error that occurred. The text it
+	// returns is presented in place of the localised message.
+	ErrorHandlerFn func(data Localisable, sourceID string, tag language.Tag, err error) string
+
+	// LanguageChangedFn is invoked when the language of the active translator
+	// has been switched.
+	LanguageChangedFn func(tag language.Tag)
+
+	// ReloadFailedFn is invoked when the translations of a source can not be
+	// reloaded. The localizer previously created for the source remains in
+	// use.
+	ReloadFailedFn func(sourceID string, err error)
+
+	// ReloadOptions controls the reloading of translation files that change
+	// while the translator is active.
+	ReloadOptions struct {
+		// Watch enables watching of the translation files of the active
+		// translator. The files of the host file system are watched for
+		// change notifications, other file systems are polled.
+		Watch bool
+
+		// Interval at which translation files are polled for changes, when
+		// change notifications are not available. Defaults to 2 seconds.
+		Interval time.Duration
+
+		// OnFailed, when set, is invoked when the translations of a source
+		// can not be reloaded.
+		OnFailed ReloadFailedFn
+	}
+
+	// MissingTranslation identifies a message that has been presented in the
+	// base language of its source, because it has not been translated into
+	// the language requested.
+	MissingTranslation struct {
+		// SourceID is the id of the source the message belongs to
+		SourceID string `json:"source"`
+
+		// MessageID is the id of the message
+		MessageID string `json:"message"`
+
+		// Tag is the language requested
+		Tag language.Tag `json:"tag"`
+
+		// Count is the number of times the message has been presented
+		// untranslated
+		Count int64 `json:"count"`
+	}
+
+	// MissingCollector records missing translations. Collect is invoked on
+	// the path of every message localised without a translation, so it must
+	// be cheap and safe to call from multiple goroutines.
+	MissingCollector interface {
+		// Collect records that the message of the source was not translated
+		// into the language requested.
+		Collect(sourceID, messageID string, tag language.Tag)
+
+		// Snapshot returns the missing translations recorded so far.
+		Snapshot() []MissingTranslation
+	}
+
+	// FallbackChains maps a language to the languages consulted, in order,
+	// when a message has not been translated into that language.
+	FallbackChains map[language.Tag][]language.Tag
+
 	// UseOptionFn functional options function required by Use.
 	UseOptionFn func(*UseOptions)
 
+	// RegisterOptionFn functional options function required by Register.
+	RegisterOptionFn func(*RegisterOptions)
+
+	// RegisterOptions the options provided by a library to the Register
+	// function, describing the library's own translation files.
+	RegisterOptions struct {
+		// SourceID identifies the library's messages, ie it is the value
+		// returned by SourceID of the library's template data.
+		SourceID string
+
+		// Name of the library's translation files, ie the files are named
+		// <name>.active.<tag>.json. When empty, files are named active.<tag>.json.
+		Name string
+
+		// Path is the directory within DefaultFS that contains the translation
+		// files. Defaults to the root of DefaultFS.
+		Path string
+
+		// DefaultFS is the file system containing the library's translation
+		// files, typically embedded in the library via go:embed. These are
+		// the translations used, unless the host application declares a
+		// translation source for the same SourceID in its call to Use.
+		DefaultFS fs.FS
+
+		// Base is the language the library's messages are authored in. If not
+		// specified, the Base language of the host applies.
+		Base language.Tag
+
+		// Supported denotes the languages the library provides translations
+		// for.
+		Supported SupportedLanguages
+	}
+
 	// UseOptions the options provided to the Use function
 	UseOptions struct {
 		// Tag sets the language to use
 		Tag language.Tag
 
+		// Base is the default language, ie the language the client's messages
+		// are authored in. Messages that can not be translated are presented
+		// in this language. Defaults to DefaultLanguage (en-GB).
+		Base language.Tag
+
+		// Supported denotes the languages that the client provides translations
+		// for. The effective set of languages available is the union of these,
+		// the languages supported by each of the sources (see
+		// TranslationSource.Supported) and the languages li18ngo supports.
+		Supported SupportedLanguages
+
+		// Fallbacks overrides the fallback chain of a language. By default, a
+		// message that is not translated into the requested language, falls
+		// back to the translation of the language's parent (eg pt-BR => pt),
+		// as determined by language.Tag.Parent, before falling back to the
+		// base language. An empty chain disables fallback for that language.
+		Fallbacks FallbackChains
+
+		// Detector, when set, is invoked to detect the language to use, from
+		// the supported languages. A detected language overrides Tag. See
+		// WithDetectedLanguage.
+		Detector DetectorFn
+
+		// OnError, when set, is invoked when a message can not be localised,
+		// for example because the localizer for the message's source could
+		// not be created or the message template is malformed. Without it,
+		// such failures are silent and an empty string is presented. See
+		// OtherOnError, MessageIDOnError and PanicOnError.
+		OnError ErrorHandlerFn
+
+		// Collector records the messages presented untranslated, because a
+		// translation for the requested language is not available. Defaults
+		// to an in-memory collector, see MissingTranslations.
+		Collector MissingCollector
+
+		// Reload controls the reloading of translation files that change
+		// while the translator is active, see ReloadOptions. Reloading can
+		// also be requested explicitly, with Reload.
+		Reload ReloadOptions
+
 		// From denotes where to load the translation file from
 		From LoadFrom
 
+		// Format of the translation files, which determines their extension
+		// (<name>.active.<tag>.<format>) and how they are decoded. If not
+		// specified, the format is detected from the files present; when a
+		// file is present in several formats, json takes precedence over yaml,
+		// which takes precedence over toml.
+		Format Format
+
+		// Discover when set, scans the directory of each source for translation
+		// files (<name>.active.<tag>.<format>) and adds the languages found to those
+		// supported. A source that does not declare its Supported languages is
+		// restricted to the languages discovered for it.
+		Discover bool
+
 		// DefaultIsAcceptable controls whether an error is returned if the
 		// request language is not available. By default DefaultIsAcceptable
 		// is true so that the application continues in the default language
@@ -105,25 +413,42 @@ type (
 		// Custom set-able by the client for what ever purpose is required.
 		Custom any
 
-		// FS is a file system from where translations are loaded from. This
-		// does not have to performed explicitly asa it will be created using
-		// the From field if not specified.
-		FS nef.ReaderFS
+		// FS is the file system translations are loaded from, eg an embed.FS.
+		// When specified, the paths of LoadFrom and of each TranslationSource
+		// are resolved within FS, (ie they are slash separated and relative
+		// to its root), and the host file system is not consulted. When not
+		// specified, translations are loaded from the host file system.
+		FS fs.FS
 	}
 
-	// LanguageInfo information pertaining to setting language. Auto detection
-	// is not supported. Any executable that supports i18n, should perform
-	// auto detection and then invoke Use, with the detected language tag
+	// LanguageInfo information pertaining to setting language. The language
+	// can be detected automatically by setting the Detector on UseOptions,
+	// or explicitly by invoking Use with the required language tag.
 	LanguageInfo struct {
 		UseOptions
 
 		// Default language reflects the base language. If all else fails, messages will
-		// be in this language. It is fixed at BritishEnglish reflecting the language this
-		// package is written in.
+		// be in this language. It is derived from UseOptions.Base, which defaults to
+		// BritishEnglish reflecting the language this package is written in.
 		Default language.Tag
 
 		// Supported indicates the list of languages for which translations are available.
 		Supported SupportedLanguages
+
+		// Discovered contains the languages found for each source, when
+		// discovery has been requested.
+		Discovered Availability
+
+		// strict indicates that a translation file that exists but can not
+		// be loaded is an error, even if the default is acceptable. Set when
+		// reloading, so that a malformed file does not replace translations
+		// that were loaded successfully.
+		strict bool
+
+		// pseudo is set when the localizer is being created for a
+		// pseudo-locale, so that the messages loaded, including those of any
+		// layer, are transformed.
+		pseudo *pseudoLocale
 	}
 
 	LocalizerInfo struct {
@@ -143,13 +468,62 @@ type (
 		// LanguageInfo returns the language information of the translator.
 		LanguageInfo() *LanguageInfo
 
+		// Reload recreates the localizers of all sources from their
+		// translation files. A source whose translations can not be loaded
+		// continues with its existing localizer.
+		Reload() error
+
+		// Provenance returns the origin of each message translated for the
+		// source, keyed by message id. A message without an origin is
+		// presented in the base language of its source.
+		Provenance(sourceID string) (map[string]MessageOrigin, error)
+
+		// Explain localises the message, describing how it was localised.
+		Explain(data Localisable) Explanation
+
 		negotiate(other Translator) (Translator, error)
 		add(info *LocalizerInfo, source *TranslationSource)
+		forLanguage(tag language.Tag) (Translator, error)
 	}
 
 	localizerContainer map[string]*i18n.Localizer
 )
 
+// supports determines whether the source provides translations for the
+// language specified. A source that does not declare its languages is
+// assumed to support any language.
+func (ts *TranslationSource) supports(tag language.Tag) bool {
+	return len(ts.Supported) == 0 || containsLanguage(ts.Supported, tag)
+}
+
+// format returns the format of the source's translation files, which is
+// empty when it is to be detected.
+func (ts *TranslationSource) format(lang *LanguageInfo) Format {
+	return lo.Ternary(ts.Format == "", lang.Format, ts.Format)
+}
+
+// base returns the language the source's messages are authored in.
+func (ts *TranslationSource) base(lang *LanguageInfo) language.Tag {
+	return lo.Ternary(ts.Base == language.Und, lang.Default, ts.Base)
+}
+
+// fallbackChain returns the languages whose translations are consulted for
+// tag, in order of precedence, starting with tag itself. The base language
+// is not included as it is served by the default message.
+func (li *LanguageInfo) fallbackChain(tag, base language.Tag) []language.Tag {
+	chain := []language.Tag{tag}
+
+	if fallbacks, found := li.Fallbacks[tag]; found {
+		return append(chain, fallbacks...)
+	}
+
+	for parent := tag.Parent(); parent != language.Und && parent != base; parent = parent.Parent() {
+		chain = append(chain, parent)
+	}
+
+	return chain
+}
+
 // AddSource adds a translation source
 func (lf *LoadFrom) AddSource(sourceID string, source *TranslationSource) {
 	if _, found := lf.Sources[sourceID]; !found {
@@ -158,6 +532,31 @@ func (lf *LoadFrom) AddSource(sourceID string, source *TranslationSource) {
 }
 
 var (
-	tx              Translator
+	// active holds the translator activated by Use/Register. Readers (Text and
+	// Render) load it without locking; writers never mutate the translator it
+	// points to, they publish a replacement instead.
+	active atomic.Pointer[Translator]
+
+	// activation serialises writers, so that negotiating the legacy translator
+	// with an incoming one can not lose a concurrent update.
+	activation sync.Mutex
+
 	DefaultLanguage = language.BritishEnglish
+
+	// PseudoAccented is the pseudo-locale whose messages are presented with
+	// accented letters, padded by about 30% and wrapped in brackets, so that
+	// untranslated, concatenated or truncated text is readily spotted.
+	PseudoAccented = language.MustParse("en-XA")
+
+	// PseudoBidi is the pseudo-locale whose messages are presented mirrored,
+	// ie forced right to left, padded and wrapped in brackets, so that the
+	// layout of right to left languages can be tested.
+	PseudoBidi = language.MustParse("ar-XB")
+
+	// li18ngoLanguages are the languages for which li18ngo provides its own
+	// translations.
+	li18ngoLanguages = SupportedLanguages{
+		language.BritishEnglish,
+		language.AmericanEnglish,
+	}
 )
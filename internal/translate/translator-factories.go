@@ -1,6 +1,8 @@
 package translate
 
 import (
+	"io/fs"
+
 	nef "github.com/snivilised/nefilim"
 )
 
@@ -15,6 +17,10 @@ func (f *translatorFactory) setup(lang *LanguageInfo) {
 	if f.Create == nil {
 		f.Create = createLocalizer
 	}
+
+	if isPseudo(lang.Tag) {
+		f.Create = pseudoCreator(f.Create)
+	}
 }
 
 // multiTranslatorFactory creates a translator instance from the provided
@@ -33,18 +39,8 @@ type multiTranslatorFactory struct {
 func (f *multiTranslatorFactory) New(lang *LanguageInfo) (Translator, error) {
 	f.setup(lang)
 
-	dirFS := lang.FS
-
-	if dirFS == nil {
-		dirFS = nef.NewReaderABS()
-	}
-
-	multi := &multiContainer{
-		localizers: make(localizerContainer),
-		queryFS:    dirFS,
-		fS:         dirFS,
-		create:     f.Create,
-	}
+	dirFS := readerFS(lang)
+	multi := newMultiContainer(dirFS, dirFS, f.Create, lang)
 
 	for id := range lang.From.Sources {
 		localizer, err := f.Create(lang, id, dirFS)
@@ -64,3 +60,13 @@ func (f *multiTranslatorFactory) New(lang *LanguageInfo) (Translator, error) {
 		languageInfo: lang,
 	}, nil
 }
+
+// readerFS returns the file system translations are loaded from, which
+// defaults to the host file system.
+func readerFS(lang *LanguageInfo) fs.FS {
+	if lang.FS != nil {
+		return lang.FS
+	}
+
+	return nef.NewReaderABS()
+}
@@ -1,22 +1,62 @@
 package translate
 
 import (
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+
 	"github.com/snivilised/li18ngo/internal/third/lo"
 	"golang.org/x/text/language"
 )
 
 // NewLanguageInfo gets a new instance of Language info from the use options
-// specified. This is specific to li18ngo. Client applications should
-// provide their own version that reflects their own defaults.
+// specified. The default language is the Base language of the options, or
+// DefaultLanguage if not specified. The supported languages are the effective set across the
+// languages declared on the options, those declared by each of the
+// translation sources and the languages that li18ngo itself supports.
 func NewLanguageInfo(o *UseOptions) *LanguageInfo {
-	return &LanguageInfo{
+	lang := &LanguageInfo{
 		UseOptions: *o,
-		Default:    DefaultLanguage,
-		Supported: SupportedLanguages{
-			DefaultLanguage,
-			language.AmericanEnglish,
-		},
+		Default:    lo.Ternary(o.Base == language.Und, DefaultLanguage, o.Base),
+	}
+
+	// the sources are copied, so that the client's map is not modified
+	lang.From.Sources = maps.Clone(o.From.Sources)
+	verifyLanguage(lang)
+	lang.Supported = effectiveLanguages(lang)
+
+	return lang
+}
+
+// effectiveLanguages computes the union of all languages supported, with the
+// default language appearing first. The sources are visited in order of
+// source id, so that the result is deterministic.
+func effectiveLanguages(lang *LanguageInfo) SupportedLanguages {
+	effective := SupportedLanguages{lang.Default}
+
+	merge := func(languages SupportedLanguages) {
+		for _, tag := range languages {
+			if !containsLanguage(effective, tag) {
+				effective = append(effective, tag)
+			}
+		}
+	}
+
+	merge(li18ngoLanguages)
+	merge(lang.UseOptions.Supported)
+
+	for _, id := range slices.Sorted(maps.Keys(lang.From.Sources)) {
+		source := lang.From.Sources[id]
+
+		if source.Base != language.Und {
+			merge(SupportedLanguages{source.Base})
+		}
+
+		merge(source.Supported)
 	}
+
+	return effective
 }
 
 // Use, must be called by the client before any string data
@@ -31,12 +71,52 @@ func NewLanguageInfo(o *UseOptions) *LanguageInfo {
 // the default language will be used. The client MUST call Use
 // before using any functionality in this package.
 func Use(options ...UseOptionFn) error {
-	var err error
+	// the language is resolved with activation held, so that a source
+	// registered concurrently is either included by newLanguage, or
+	// registered with the translator activated here, rather than with an
+	// implicit translator about to be replaced.
+	activation.Lock()
+	defer activation.Unlock()
+
+	lang, err := newLanguage(options...)
+	if err != nil {
+		return err
+	}
+
+	// a translator activated implicitly by Register is replaced rather than
+	// negotiated with, as it does not reflect the host's choices; the sources
+	// registered are not lost as they are included by newLanguage.
+	legacy := lo.Ternary(implicit, nil, current())
+
+	negotiated, err := applyLanguage(lang, legacy)
+	if err == nil {
+		activate(negotiated)
+		rewatch(negotiated)
+		implicit = false
+	}
+
+	return err
+}
+
+// NewTranslator creates a translator from the options provided, in the same
+// way as Use, except that the translator is not activated. The result is
+// independent of the translator used by Text and Render, so that a client
+// can hold a translator per language and select the appropriate one per
+// request.
+func NewTranslator(options ...UseOptionFn) (Translator, error) {
+	lang, err := newLanguage(options...)
+	if err != nil {
+		return nil, err
+	}
 
+	return createTranslator(lang, nil)
+}
+
+// newLanguage applies the options and resolves the language to be used.
+func newLanguage(options ...UseOptionFn) (*LanguageInfo, error) {
 	o := &UseOptions{}
 
 	o.DefaultIsAcceptable = true
-	o.Tag = DefaultLanguage
 
 	for _, fo := range options {
 		fo(o)
@@ -44,35 +124,73 @@ func Use(options ...UseOptionFn) error {
 
 	lang := NewLanguageInfo(o)
 
-	if !containsLanguage(lang.Supported, o.Tag) {
-		if o.DefaultIsAcceptable {
-			o.Tag = DefaultLanguage
-			lang.Tag = o.Tag
-		} else {
-			err = NewFailedToCreateTranslatorNativeError(o.Tag)
+	if includeRegistered(lang) {
+		lang.Supported = effectiveLanguages(lang)
+	}
+
+	if lang.Tag == language.Und {
+		lang.Tag = lang.Default
+	}
+
+	if o.Discover {
+		lang.Discovered = discover(lang, readerFS(lang))
+		lang.Supported = effectiveLanguages(lang)
+	}
+
+	if o.Detector != nil {
+		if tag, confidence := o.Detector(lang.Supported); confidence != language.No {
+			lang.Tag = tag
 		}
 	}
 
-	if err == nil {
-		tx, err = applyLanguage(lang, tx)
+	if !containsLanguage(lang.Supported, lang.Tag) && !isPseudo(lang.Tag) {
+		if !o.DefaultIsAcceptable {
+			return nil, NewFailedToCreateTranslatorNativeError(lang.Tag)
+		}
+
+		lang.Tag = lang.Default
 	}
 
-	return err
+	return lang, nil
 }
 
 func ResetTx() {
 	// required only for unit tests
 	//
-	tx = nil
+	activation.Lock()
+	defer activation.Unlock()
+
+	active.Store(nil)
+	rewatch(nil)
+	implicit = false
+	resetRegistry()
+	defaultCollector.reset()
+}
+
+// current returns the active translator, or nil if neither Use nor Register
+// has been invoked.
+func current() Translator {
+	if p := active.Load(); p != nil {
+		return *p
+	}
+
+	return nil
+}
+
+// activate publishes the translator, making it visible to all subsequent
+// invocations of Text and Render.
+func activate(t Translator) {
+	active.Store(&t)
 }
 
 // Text is the function to use to obtain a string created from
 // registered Localizers. The data parameter must be a go template
 // defining the input parameters and the translatable message content.
 // Will panic with ErrSafePanicWarning, if the Use function has not been
-// called before invoking Text.
+// called before invoking Text. Text is lock-free and safe to call from
+// multiple goroutines, including concurrently with Use and Register.
 func Text(data Localisable) string {
-	if tx != nil {
+	if tx := current(); tx != nil {
 		return tx.Localise(data)
 	}
 
@@ -84,23 +202,13 @@ func Text(data Localisable) string {
 // to the canonical English string defined in data.Message().Other. Library
 // authors should use Render (via LocalisableError) rather than Text.
 func Render(data Localisable) string {
-	if tx != nil {
+	if tx := current(); tx != nil {
 		return tx.Localise(data)
 	}
 
 	return data.Message().Other
 }
 
-// Register is the library-tier equivalent of Use. A library that depends on
-// li18ngo should call Register to add its translation sources to the active
-// translator. Libraries must never call Use - that is an application
-// bootstrap concern.
-func Register(options ...UseOptionFn) error {
-	// Same mechanics as Use; the distinction is semantic and enforced by
-	// convention. A future lint rule can enforce this boundary.
-	return Use(options...)
-}
-
 func containsLanguage(languages SupportedLanguages, tag language.Tag) bool {
 	return lo.ContainsBy(languages, func(t language.Tag) bool {
 		return t == tag
@@ -110,28 +218,87 @@ func containsLanguage(languages SupportedLanguages, tag language.Tag) bool {
 type i18nTranslator struct {
 	mx           *multiContainer
 	languageInfo *LanguageInfo
+
+	// derived caches translators created by forLanguage, keyed by tag
+	derived sync.Map
+
+	// reloading serialises reloads, so that a localizer can not be replaced
+	// by one created from older content
+	reloading sync.Mutex
 }
 
 func (t *i18nTranslator) LanguageInfo() *LanguageInfo {
 	return t.languageInfo
 }
 
+// Localise localises the message. Localise should be easy to use without
+// the need to handle an error that should not really ever happen, so any
+// error is passed to the OnError handler, if one has been set, which
+// determines the text presented instead.
 func (t *i18nTranslator) Localise(data Localisable) string {
-	// TODO: the error is ignored for now, but we should enable
-	// the client to register an error hander. Localise should
-	// be easy to use without worrying about the need to handle
-	// an error that should not really ever happen. Or we revert
-	// to default. There is no reason to ever return an error here.
-	//
-	text, _ := t.mx.localise(data)
+	text, err := t.mx.localise(data)
+
+	if err != nil && t.languageInfo.OnError != nil {
+		return t.languageInfo.OnError(data, data.SourceID(), t.languageInfo.Tag, err)
+	}
+
 	return text
 }
 
+// Reload recreates the localizers of all sources from their translation
+// files.
+func (t *i18nTranslator) Reload() error {
+	return t.reload(slices.Collect(maps.Keys(t.languageInfo.From.Sources)))
+}
+
+// reload recreates the localizers of the sources specified, replacing each
+// atomically, so that concurrent readers observe either the previous or the
+// new localizer. When a localizer can not be created, the previous one is
+// retained and the OnFailed handler is invoked.
+func (t *i18nTranslator) reload(sourceIDs []string) error {
+	t.reloading.Lock()
+	defer t.reloading.Unlock()
+
+	lang := *t.languageInfo
+	lang.strict = true
+
+	var errs []error
+
+	for _, id := range sourceIDs {
+		localizer, err := t.mx.create(&lang, id, t.mx.fS)
+
+		if err != nil {
+			if t.languageInfo.Reload.OnFailed != nil {
+				t.languageInfo.Reload.OnFailed(id, err)
+			}
+
+			errs = append(errs, err)
+
+			continue
+		}
+
+		t.mx.replace(&LocalizerInfo{
+			Localizer: localizer,
+			SourceID:  id,
+		})
+	}
+
+	// translators derived for other languages are discarded, so that they
+	// are recreated from the current content on demand.
+	t.derived.Clear()
+
+	return errors.Join(errs...)
+}
+
 func (t *i18nTranslator) add(info *LocalizerInfo, source *TranslationSource) {
 	t.mx.add(info)
 	t.languageInfo.From.AddSource(info.SourceID, source)
 }
 
+// negotiate returns a translator that combines the sources of this translator
+// with any new sources found in the incoming translator. The legacy translator
+// may be in use by other goroutines, so it is never modified; the sources are
+// merged into a clone instead.
 func (t *i18nTranslator) negotiate(incomingTX Translator) (Translator, error) {
 	incomingLang := incomingTX.LanguageInfo()
 	legacyLang := t.LanguageInfo()
@@ -143,18 +310,61 @@ func (t *i18nTranslator) negotiate(incomingTX Translator) (Translator, error) {
 
 	legacySources := legacyLang.From.Sources
 	incomingSources := incomingLang.From.Sources
+	negotiated := t.clone()
 
 	for sourceID, source := range incomingSources {
 		if _, found := legacySources[sourceID]; !found {
-			s := source // copy required to avoid "implicit memory aliasing in for loop"
-			t.add(&LocalizerInfo{
-				Localizer: incTX.mx.localizers[sourceID],
+			localizer, _ := incTX.mx.find(sourceID)
+			negotiated.add(&LocalizerInfo{
+				Localizer: localizer,
 				SourceID:  sourceID,
-			}, &s)
+			}, &source)
 		}
 	}
 
-	return t, nil
+	return negotiated, nil
+}
+
+// clone creates a copy of the translator that can be modified without
+// affecting readers of the original.
+func (t *i18nTranslator) clone() *i18nTranslator {
+	lang := *t.languageInfo
+	lang.From.Sources = maps.Clone(t.languageInfo.From.Sources)
+
+	return &i18nTranslator{
+		mx:           t.mx.clone(),
+		languageInfo: &lang,
+	}
+}
+
+// forLanguage returns a translator with the same sources as this translator
+// but for the language specified. Derived translators are cached, so the
+// localizers for a language are only created once per translator.
+func (t *i18nTranslator) forLanguage(tag language.Tag) (Translator, error) {
+	if tag == t.languageInfo.Tag {
+		return t, nil
+	}
+
+	if derived, found := t.derived.Load(tag); found {
+		return derived.(Translator), nil
+	}
+
+	if !containsLanguage(t.languageInfo.Supported, tag) && !isPseudo(tag) {
+		return nil, NewFailedToCreateTranslatorNativeError(tag)
+	}
+
+	lang := *t.languageInfo
+	lang.Tag = tag
+	lang.From.Sources = maps.Clone(t.languageInfo.From.Sources)
+
+	derived, err := createTranslator(&lang, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := t.derived.LoadOrStore(tag, derived)
+
+	return actual.(Translator), nil
 }
 
 func verifyLanguage(lang *LanguageInfo) {
@@ -182,15 +392,7 @@ func verifyLanguage(lang *LanguageInfo) {
 }
 
 func applyLanguage(lang *LanguageInfo, tx Translator) (Translator, error) {
-	verifyLanguage(lang)
-	factory := &multiTranslatorFactory{
-		translatorFactory: translatorFactory{
-			Create: lang.Create,
-			legacy: tx,
-		},
-	}
-
-	newTranslator, err := factory.New(lang)
+	newTranslator, err := createTranslator(lang, tx)
 	if err != nil {
 		return nil, err
 	}
@@ -203,6 +405,18 @@ func applyLanguage(lang *LanguageInfo, tx Translator) (Translator, error) {
 	return negotiatedTX, err
 }
 
+func createTranslator(lang *LanguageInfo, legacy Translator) (Translator, error) {
+	verifyLanguage(lang)
+	factory := &multiTranslatorFactory{
+		translatorFactory: translatorFactory{
+			Create: lang.Create,
+			legacy: legacy,
+		},
+	}
+
+	return factory.New(lang)
+}
+
 func negotiateTranslators(legacyTX, incomingTX Translator) (Translator, error) {
 	var (
 		err error
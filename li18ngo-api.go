@@ -5,12 +5,51 @@ import (
 	nef "github.com/snivilised/nefilim"
 )
 
+const (
+	// FormatJSON denotes translation files in json
+	FormatJSON = translate.FormatJSON
+
+	// FormatYAML denotes translation files in yaml
+	FormatYAML = translate.FormatYAML
+
+	// FormatTOML denotes translation files in toml
+	FormatTOML = translate.FormatTOML
+
+	// ResolutionDeclared denotes a localizer created for a declared source
+	ResolutionDeclared = translate.ResolutionDeclared
+
+	// ResolutionOnDemand denotes a localizer created on demand, in the base
+	// language, for a source that has not been declared
+	ResolutionOnDemand = translate.ResolutionOnDemand
+
+	// ResolutionNone denotes that there is no active translator
+	ResolutionNone = translate.ResolutionNone
+
+	// TraceEnv is the environment variable (LI18NGO_TRACE) that enables the
+	// trace of the translation files resolved and loaded whenever a
+	// localizer is created; "1" traces to stderr, otherwise the value is
+	// the path of the file the trace is appended to.
+	TraceEnv = translate.TraceEnv
+)
+
 var (
 	// 🌐 translate
 
-	// DefaultLanguage represents the default language of this module
+	// DefaultLanguage represents the default language of this module. It is
+	// the base language of a translator, unless UseOptions.Base specifies
+	// otherwise.
 	DefaultLanguage = translate.DefaultLanguage
 
+	// PseudoAccented is the pseudo-locale (en-XA) that presents messages
+	// with accented letters, padded and wrapped in brackets. Activate it
+	// with Use, as for any other language, to test the UI without real
+	// translations.
+	PseudoAccented = translate.PseudoAccented
+
+	// PseudoBidi is the pseudo-locale (ar-XB) that presents messages
+	// mirrored, ie forced right to left, padded and wrapped in brackets.
+	PseudoBidi = translate.PseudoBidi
+
 	// ErrSafePanicWarning is emitted via panic if application code calls Text
 	// before Use has been invoked. Library code should use Describe instead,
 	// which falls back gracefully to the canonical message string.
@@ -28,9 +67,32 @@ var (
 	// Text is the function to use to obtain a string created from
 	// registered Localizers. The data parameter must be a go template
 	// defining the input parameters and the translatable message content.
-	// Not threadsafe.
+	// Text is safe for concurrent use: it reads the active translator without
+	// locking and may be invoked from many goroutines while Use or Register
+	// are being called; each call sees either the previous or the new
+	// translator, never a partially updated one.
 	Text = translate.Text
 
+	// TextCtx is the context aware version of Text. The translator is taken
+	// from the context (see WithTranslator), or derived from the active
+	// translator for the language in the context (see WithLanguage). When
+	// the context contains neither, TextCtx is equivalent to Text.
+	TextCtx = translate.TextCtx
+
+	// RenderCtx is the context aware version of Render; it resolves the
+	// translator in the same manner as TextCtx and is safe to call before
+	// Use has been invoked.
+	RenderCtx = translate.RenderCtx
+
+	// WithLanguage returns a copy of the context that requests messages be
+	// localised into the language specified, falling back to the translator
+	// set by Use if that language is not supported.
+	WithLanguage = translate.WithLanguage
+
+	// WithTranslator returns a copy of the context that carries the
+	// translator specified, typically one created by NewTranslator.
+	WithTranslator = translate.WithTranslator
+
 	// Render is the library-tier localisation function. It is safe to call
 	// even if Use has not been called by the host application - it falls back
 	// to the canonical English string defined in data.Message().Other. Library
@@ -49,10 +111,94 @@ var (
 	// this package.
 	Use = translate.Use
 
+	// NewTranslator creates a Translator from the options provided, just as
+	// Use does, but without activating it. Each translator is independent,
+	// so a server can hold one per locale and select the appropriate one
+	// per request, without relying on the process-wide translator.
+	NewTranslator = translate.NewTranslator
+
+	// Available returns the languages discovered for each source by the
+	// active translator, when UseOptions.Discover is set. This is useful for
+	// reporting the languages available, eg in help text.
+	Available = translate.Available
+
+	// Detect matches the preferred languages against the supported languages,
+	// returning the best supported tag and the confidence of the match.
+	Detect = translate.Detect
+
+	// DetectEnv detects the language from the LC_ALL, LC_MESSAGES and LANG
+	// environment variables (in that order of precedence).
+	DetectEnv = translate.DetectEnv
+
+	// DetectAcceptLanguage detects the language from the value of a HTTP
+	// Accept-Language header.
+	DetectAcceptLanguage = translate.DetectAcceptLanguage
+
+	// WithDetectedLanguage is an option for Use, that sets the language
+	// detected from the environment, against the supported languages.
+	WithDetectedLanguage = translate.WithDetectedLanguage
+
+	// SwitchLanguage switches the active translator to another language,
+	// recreating the localizers of all its sources.
+	SwitchLanguage = translate.SwitchLanguage
+
+	// OnLanguageChanged registers a function invoked whenever the language
+	// is switched, returning a function that unsubscribes.
+	OnLanguageChanged = translate.OnLanguageChanged
+
+	// Reload recreates the localizers of the active translator from their
+	// translation files.
+	Reload = translate.Reload
+
+	// Provenance returns the origin of each message the active translator
+	// has translated for a source, reporting the layer that defined it.
+	Provenance = translate.Provenance
+
+	// Explain localises a message with the active translator, describing
+	// the localizer resolved, the language matched, the translation file
+	// that defined it, or whether the default message was used, and the
+	// final text.
+	Explain = translate.Explain
+
+	// TemplateFuncs returns the locale aware template functions (number,
+	// percent, currency, date, duration and bytes) available to every
+	// message template, for the language. They are emitted by lingo for
+	// the fields of a message that declare a Format.
+	TemplateFuncs = translate.TemplateFuncs
+
+	// MissingTranslations returns a snapshot of the messages that have been
+	// presented untranslated by the active translator.
+	MissingTranslations = translate.MissingTranslations
+
+	// DumpMissingTranslations periodically writes the missing translations,
+	// as json, to a file until the context is cancelled.
+	DumpMissingTranslations = translate.DumpMissingTranslations
+
+	// NewMissingCollector creates an in-memory collector of missing
+	// translations.
+	NewMissingCollector = translate.NewMissingCollector
+
+	// WithErrorHandler is an option for Use, that sets the handler invoked
+	// when a message can not be localised.
+	WithErrorHandler = translate.WithErrorHandler
+
+	// OtherOnError is an error handler that presents the untranslated Other
+	// form of a message that can not be localised.
+	OtherOnError = translate.OtherOnError
+
+	// MessageIDOnError is an error handler that presents the id of a message
+	// that can not be localised.
+	MessageIDOnError = translate.MessageIDOnError
+
+	// PanicOnError is an error handler that panics when a message can not be
+	// localised; intended for use in tests.
+	PanicOnError = translate.PanicOnError
+
 	// Register is the library-tier equivalent of Use. A library that depends on
-	// li18ngo should call Register to add its translation sources to the active
-	// translator. Libraries must never call Use - that is an application
-	// bootstrap concern.
+	// li18ngo should call Register to declare its own translation files, which
+	// are merged into the host's translator, regardless of whether the host
+	// calls Use before or after. Libraries must never call Use - that is an
+	// application bootstrap concern.
 	Register = translate.Register
 )
 
@@ -71,12 +217,53 @@ type (
 	// LoadFrom denotes where to load the translation file from
 	LoadFrom = translate.LoadFrom
 
+	// Localisable represents the data required to localise a message.
+	Localisable = translate.Localisable
+
+	// Countable is optionally implemented by Localisable data for messages
+	// that have plural forms, to provide the count that selects the form.
+	Countable = translate.Countable
+
 	// LocalisableError is an error that is translate-able (Localisable)
 	LocalisableError = translate.LocalisableError
 
-	// LanguageInfo information pertaining to setting language. Auto detection
-	// is not supported. Any executable that supports i18n, should perform
-	// auto detection and then invoke Use, with the detected language tag
+	// Availability maps a source id to the languages for which translation
+	// files have been discovered.
+	Availability = translate.Availability
+
+	// DetectorFn resolves the language to use from the languages supported.
+	DetectorFn = translate.DetectorFn
+
+	// LanguageChangedFn is invoked when the language has been switched.
+	LanguageChangedFn = translate.LanguageChangedFn
+
+	// ReloadOptions controls the reloading of translation files that change
+	// while the translator is active.
+	ReloadOptions = translate.ReloadOptions
+
+	// ReloadFailedFn is invoked when the translations of a source can not be
+	// reloaded.
+	ReloadFailedFn = translate.ReloadFailedFn
+
+	// MissingTranslation identifies a message that has been presented
+	// untranslated.
+	MissingTranslation = translate.MissingTranslation
+
+	// MissingCollector records missing translations.
+	MissingCollector = translate.MissingCollector
+
+	// ErrorHandlerFn is invoked when a message can not be localised, to
+	// determine the text presented in its place.
+	ErrorHandlerFn = translate.ErrorHandlerFn
+
+	// FallbackChains maps a language to the languages consulted, in order,
+	// when a message has not been translated into that language.
+	FallbackChains = translate.FallbackChains
+
+	// LanguageInfo information pertaining to setting language. The language
+	// can be detected automatically by setting the Detector on UseOptions
+	// (see WithDetectedLanguage), or explicitly by invoking Use with the
+	// required language tag.
 	LanguageInfo = translate.LanguageInfo
 
 	// LocalizerCreatorFn represents the signature of the function that can
@@ -93,8 +280,31 @@ type (
 	// eg li18ngo.active.en-GB.json.
 	// Path: file system path to the translation file. If missing, then
 	// it will default to the location of the executable file.
+	// Layers: overrides stacked on top of the source's own translations.
 	TranslationSource = translate.TranslationSource
 
+	// Layer is a directory of translation files that override those of a
+	// source; see TranslationSource.Layers.
+	Layer = translate.Layer
+
+	// MessageOrigin identifies the translation file a message was loaded
+	// from, see Provenance.
+	MessageOrigin = translate.MessageOrigin
+
+	// Explanation describes how a message was localised, see Explain.
+	Explanation = translate.Explanation
+
+	// Resolution denotes how the localizer of a message was resolved.
+	Resolution = translate.Resolution
+
+	// Format is the format of a translation file (json, yaml or toml), which
+	// is also the file's extension.
+	Format = translate.Format
+
+	// Translator localises messages in a single language; see NewTranslator.
+	// The interface can only be implemented inside li18ngo.
+	Translator = translate.Translator
+
 	// TranslationFiles maps a source id to a TranslationSource
 	TranslationFiles = translate.TranslationFiles
 
@@ -103,4 +313,11 @@ type (
 
 	// UseOptionFn functional options function required by Use.
 	UseOptionFn = translate.UseOptionFn
+
+	// RegisterOptions the options provided by a library to the Register
+	// function, describing the library's own translation files.
+	RegisterOptions = translate.RegisterOptions
+
+	// RegisterOptionFn functional options function required by Register.
+	RegisterOptionFn = translate.RegisterOptionFn
 )
@@ -22,6 +22,15 @@ type UnderlyingType uint
 //   - Fields non-empty on UnderlyingTypeErrorStaticWrapper
 //   - {{.Wrapped}} in Other on a non-wrapper TypeName
 //   - Duplicate MessageID across the map
+//   - More than one Fields entry designated as the PluralCount
+//   - PluralCount designated on a Fields entry with a non-numeric GoType
+//   - Plural forms (Zero/One/Two/Few/Many) without a PluralCount field, or
+//     a PluralCount field without plural forms
+//   - {{.Token}} in a plural form with no matching Fields entry
+//   - Fields entry named "PluralCount"
+//   - Fields entry with an unknown Format
+//   - Format not applicable to the Fields entry's GoType, eg date on a
+//     non time.Time field
 //
 // =============================================================================
 const (
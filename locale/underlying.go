@@ -13,6 +13,19 @@ type UnderlyingField struct {
 	// Tale is the doc comment emitted for this field in the generated struct.
 	// If Tale is empty a 🔥 TODO reminder is emitted instead.
 	Tale string
+
+	// PluralCount designates this field as the count that selects the plural
+	// form of the message. At most one field may be designated and its GoType
+	// must be numeric. lingo generates a PluralCount method returning this
+	// field, which is passed to go-i18n at localisation time.
+	PluralCount bool
+
+	// Format optionally denotes how the field is formatted in the active
+	// language, one of "number", "percent" or "bytes" (numeric GoType),
+	// "date" (GoType "time.Time") or "duration" (GoType "time.Duration").
+	// lingo emits the {{.<Note>}} tokens of the field as {{<Format> .<Note>}}
+	// in the generated message, see li18ngo.TemplateFuncs.
+	Format string
 }
 
 // UnderlyingTemplData is the descriptor for a single i18n message.
@@ -45,6 +58,17 @@ type UnderlyingTemplData struct {
 	// a matching Fields entry.
 	Other string
 
+	// Zero, One, Two, Few and Many are the optional CLDR plural forms of the
+	// message. The form selected depends on the value of the field designated
+	// as the PluralCount and the plural rules of the language; Other is used
+	// when the selected form is not defined. When any of these is defined,
+	// exactly one field must be designated as the PluralCount.
+	Zero string
+	One  string
+	Two  string
+	Few  string
+	Many string
+
 	// Fields lists the variable fields for dynamic messages. Must be
 	// empty for static types and non-empty for dynamic types. For
 	// wrapper types exactly one entry must have GoType "error" and
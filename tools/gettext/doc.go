@@ -0,0 +1,4 @@
+// Package gettext converts li18ngo translation message files (go-i18n json)
+// to and from gettext po and mo files, for translators whose tools work in
+// gettext.
+package gettext
@@ -0,0 +1,78 @@
+package gettext
+
+import (
+	"github.com/snivilised/li18ngo/tools/sorter"
+)
+
+// 📚 pkg: gettext - converts message files to and from gettext
+
+const (
+	// hashFlag prefixes the flag that carries the hash of a message
+	hashFlag = "hash="
+
+	// categoriesHeader is the header that records the plural category
+	// selected by each msgstr[n] of a plural message, since gettext denotes
+	// plural forms by index, whereas go-i18n denotes them by CLDR category.
+	categoriesHeader = "X-Plural-Categories"
+
+	// contextSeparator separates the msgctxt from the msgid of a message key
+	// in a mo file
+	contextSeparator = "\x04"
+
+	// pluralSeparator separates the msgid from the msgid_plural of a message
+	// key and the translations of a plural message in a mo file
+	pluralSeparator = "\x00"
+)
+
+var (
+	// defaultCategories are the plural categories assumed for a gettext file
+	// without the categories header, which covers the many languages with
+	// the plural forms, nplurals=2; plural=(n != 1);
+	defaultCategories = []string{"one", "other"}
+)
+
+type (
+	// entry is a message of a go-i18n message file, as imported. Unlike a
+	// sorter.HashedMessageEntry, the hash is omitted for a message that does
+	// not have one, so that such a message is imported as it was exported.
+	entry struct {
+		sorter.MessageEntry
+		Hash string `json:"hash,omitempty"`
+	}
+
+	// Options controls the export of a message file to gettext.
+	Options struct {
+		// Language of the translations, written to the Language header
+		Language string
+
+		// Source is the message file of the base language, whose text is
+		// presented to the translator as the msgid of each message. When
+		// not specified, the message id is presented instead.
+		Source []byte
+	}
+
+	// message is a gettext message. The header is represented as the message
+	// with an empty msgctxt and msgid, whose translation is the header.
+	message struct {
+		// comments are the translator comments, which carry the description
+		comments []string
+
+		// flags are the flags, one of which carries the hash
+		flags []string
+
+		// context is the msgctxt, which carries the message id
+		context string
+
+		// id is the msgid, which carries the source text
+		id string
+
+		// plural is the msgid_plural
+		plural string
+
+		// translations are the msgstr, or msgstr[n] for a plural message
+		translations []string
+
+		// isPlural denotes a message with plural forms
+		isPlural bool
+	}
+)
@@ -0,0 +1,13 @@
+package gettext_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGettext(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gettext Suite")
+}
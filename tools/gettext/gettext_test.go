@@ -0,0 +1,92 @@
+package gettext_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/snivilised/li18ngo/tools/gettext"
+	"github.com/snivilised/li18ngo/tools/sorter"
+)
+
+const (
+	polish = `{
+  "files-found.plural": {
+    "description": "Number of files found",
+    "hash": "sha1-5f8f7d5c0e3a1b6f0c2f7b9a8e4d3c2b1a0f9e8d",
+    "other": "Znaleziono {{.Count}} pliku",
+    "one": "Znaleziono {{.Count}} plik",
+    "few": "Znaleziono {{.Count}} pliki",
+    "many": "Znaleziono {{.Count}} plików"
+  },
+  "quoted.message": {
+    "description": "A message with \"quotes\",\nover two lines",
+    "other": "Plik \"{{.Name}}\"\n\tnie istnieje\\"
+  }
+}`
+)
+
+var _ = Describe("Gettext", func() {
+	decode := func(data []byte) map[string]sorter.HashedMessageEntry {
+		var messages map[string]sorter.HashedMessageEntry
+		Expect(json.Unmarshal(data, &messages)).To(Succeed())
+
+		return messages
+	}
+
+	Context("po", func() {
+		It("🧪 should: round-trip message file without loss", func() {
+			po, err := gettext.ExportPO([]byte(polish), gettext.Options{Language: "pl"})
+			Expect(err).To(Succeed())
+
+			imported, err := gettext.ImportPO(po)
+			Expect(err).To(Succeed())
+			Expect(imported).To(MatchJSON(polish))
+		})
+
+		It("🧪 should: derive plural expression from the rules of the language", func() {
+			po, err := gettext.ExportPO([]byte(polish), gettext.Options{Language: "pl"})
+			Expect(err).To(Succeed())
+
+			Expect(string(po)).To(ContainSubstring(`"Plural-Forms: nplurals=4; plural=((n == 1) ? 0 : ` +
+				`(n % 10 >= 2 && n % 10 <= 4 && (n % 100 < 12 || n % 100 > 14)) ? 1 : ` +
+				`((n != 1 && n % 10 <= 1) || (n % 10 >= 5 && n % 10 <= 9) || (n % 100 >= 12 && n % 100 <= 14)) ? 2 : 3);\n"`,
+			))
+			Expect(string(po)).To(ContainSubstring(`"X-Plural-Categories: one few many other\n"`))
+		})
+
+		It("🧪 should: omit plural forms header for language without known rules", func() {
+			po, err := gettext.ExportPO([]byte(polish), gettext.Options{Language: "xx"})
+			Expect(err).To(Succeed())
+
+			Expect(string(po)).NotTo(ContainSubstring("Plural-Forms"))
+			Expect(string(po)).To(ContainSubstring(`"X-Plural-Categories: one few many other\n"`))
+		})
+	})
+
+	Context("mo", func() {
+		It("🧪 should: round-trip translations", func() {
+			mo, err := gettext.ExportMO([]byte(polish), gettext.Options{Language: "pl"})
+			Expect(err).To(Succeed())
+
+			imported, err := gettext.ImportMO(mo)
+			Expect(err).To(Succeed())
+
+			messages := decode(imported)
+			original := decode([]byte(polish))
+			Expect(messages).To(HaveLen(len(original)))
+
+			for id, message := range original {
+				Expect(messages).To(HaveKey(id))
+				Expect(messages[id].MessageEntry).To(Equal(sorter.MessageEntry{
+					Other: message.Other,
+					One:   message.One,
+					Few:   message.Few,
+					Many:  message.Many,
+				}), "descriptions are not carried by a mo file")
+				Expect(messages[id].Hash).To(BeEmpty(), "hashes are not carried by a mo file")
+			}
+		})
+	})
+})
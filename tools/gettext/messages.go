@@ -0,0 +1,172 @@
+package gettext
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/snivilised/li18ngo/internal/third/lo"
+	"github.com/snivilised/li18ngo/tools/sorter"
+)
+
+// fromJSON converts a go-i18n message file to gettext messages, headed by
+// the header.
+func fromJSON(data []byte, options Options) ([]message, error) {
+	var translations, sources map[string]sorter.HashedMessageEntry
+
+	if err := json.Unmarshal(data, &translations); err != nil {
+		return nil, err
+	}
+
+	if options.Source != nil {
+		if err := json.Unmarshal(options.Source, &sources); err != nil {
+			return nil, err
+		}
+	}
+
+	used := usedCategories(translations)
+	messages := []message{
+		{translations: []string{header(options.Language, used)}},
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(translations)) {
+		entry := translations[id]
+		source, found := sources[id]
+
+		m := message{
+			comments: lo.Ternary(entry.Description == "", nil,
+				strings.Split(entry.Description, "\n"),
+			),
+			context: id,
+			id: lo.Ternary(found,
+				lo.Ternary(source.One != "", source.One, source.Other),
+				id,
+			),
+		}
+
+		if entry.Hash != "" {
+			m.flags = []string{hashFlag + entry.Hash}
+		}
+
+		if entry.IsPlural() {
+			m.isPlural = true
+			m.plural = lo.Ternary(found, source.Other, id)
+
+			for _, category := range used {
+				m.translations = append(m.translations, entry.Form(category))
+			}
+		} else {
+			m.translations = []string{entry.Other}
+		}
+
+		messages = append(messages, m)
+	}
+
+	return messages, nil
+}
+
+// toJSON converts gettext messages to a go-i18n message file. The message id
+// is taken from the msgctxt, or for a message without one, from the msgid.
+func toJSON(messages []message) ([]byte, error) {
+	used := defaultCategories
+	entries := make(map[string]entry, len(messages))
+
+	for _, m := range messages {
+		if m.context == "" && m.id == "" && len(m.translations) > 0 {
+			used = headerCategories(m.translations[0])
+		}
+	}
+
+	for _, m := range messages {
+		if m.context == "" && m.id == "" {
+			continue
+		}
+
+		id := lo.Ternary(m.context != "", m.context, m.id)
+		imported := entry{
+			MessageEntry: sorter.MessageEntry{
+				Description: strings.Join(m.comments, "\n"),
+			},
+		}
+
+		for _, flag := range m.flags {
+			if hash, found := strings.CutPrefix(flag, hashFlag); found {
+				imported.Hash = hash
+			}
+		}
+
+		if m.isPlural {
+			if len(m.translations) > len(used) {
+				return nil, fmt.Errorf("message '%v' has %v plural forms, but only %v categories (%v)",
+					id, len(m.translations), len(used), strings.Join(used, " "),
+				)
+			}
+
+			for i, translation := range m.translations {
+				imported.SetForm(used[i], translation)
+			}
+		} else if len(m.translations) > 0 {
+			imported.Other = m.translations[0]
+		}
+
+		entries[id] = imported
+	}
+
+	return json.MarshalIndent(entries, "", "  ")
+}
+
+// header returns the gettext header, recording the plural categories used.
+func header(language string, used []string) string {
+	var builder strings.Builder
+
+	builder.WriteString("MIME-Version: 1.0\n")
+	builder.WriteString("Content-Type: text/plain; charset=UTF-8\n")
+	builder.WriteString("Content-Transfer-Encoding: 8bit\n")
+
+	if language != "" {
+		fmt.Fprintf(&builder, "Language: %v\n", language)
+	}
+
+	if len(used) > 0 {
+		// a Plural-Forms header without a plural expression is malformed, so
+		// the header is omitted when the expression can not be derived; the
+		// categories header still records the category of each msgstr[n].
+		if forms, found := pluralForms(language, used); found {
+			fmt.Fprintf(&builder, "Plural-Forms: %v\n", forms)
+		}
+		fmt.Fprintf(&builder, "%v: %v\n", categoriesHeader, strings.Join(used, " "))
+	}
+
+	return builder.String()
+}
+
+// headerCategories returns the plural categories recorded in the header
+func headerCategories(header string) []string {
+	for line := range strings.SplitSeq(header, "\n") {
+		if value, found := strings.CutPrefix(line, categoriesHeader+":"); found {
+			return strings.Fields(value)
+		}
+	}
+
+	return defaultCategories
+}
+
+// usedCategories returns the plural categories used by the messages that
+// have plural forms, in canonical order.
+func usedCategories(entries map[string]sorter.HashedMessageEntry) []string {
+	var used []string
+
+	for _, category := range sorter.Categories {
+		for _, entry := range entries {
+			if entry.IsPlural() &&
+				(category == "other" || entry.Form(category) != "") {
+				used = append(used, category)
+				break
+			}
+		}
+	}
+
+	return used
+}
@@ -0,0 +1,167 @@
+package gettext
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"strings"
+)
+
+const (
+	// moMagic identifies a mo file, in its byte order
+	moMagic = 0x950412de
+
+	// moHeaderSize is the size of the fixed header of a mo file
+	moHeaderSize = 28
+)
+
+// ExportMO converts a go-i18n message file to a gettext mo file. A mo file
+// does not carry comments or flags, so the descriptions and hashes of the
+// messages are not exported; use ExportPO where the message file is to be
+// recreated without loss.
+func ExportMO(data []byte, options Options) ([]byte, error) {
+	messages, err := fromJSON(data, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeMO(messages), nil
+}
+
+// ImportMO converts a gettext mo file to a go-i18n message file, with empty
+// descriptions and without hashes.
+func ImportMO(data []byte) ([]byte, error) {
+	messages, err := decodeMO(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return toJSON(messages)
+}
+
+// encodeMO writes the messages in little endian order, without a hash table,
+// which is optional.
+func encodeMO(messages []message) []byte {
+	type pair struct {
+		key, value string
+	}
+
+	pairs := make([]pair, 0, len(messages))
+
+	for _, m := range messages {
+		key := m.id
+
+		if m.isPlural {
+			key += pluralSeparator + m.plural
+		}
+
+		if m.context != "" {
+			key = m.context + contextSeparator + key
+		}
+
+		pairs = append(pairs, pair{
+			key:   key,
+			value: strings.Join(m.translations, pluralSeparator),
+		})
+	}
+
+	// the keys must be sorted, as they are binary searched
+	slices.SortFunc(pairs, func(a, b pair) int {
+		return strings.Compare(a.key, b.key)
+	})
+
+	count := uint32(len(pairs)) //nolint:gosec // ok, bounded by the message file
+	originals := uint32(moHeaderSize)
+	translations := originals + count*8
+	offset := translations + count*8
+
+	var buf, strs bytes.Buffer
+
+	for _, field := range []uint32{moMagic, 0, count, originals, translations, 0, offset} {
+		_ = binary.Write(&buf, binary.LittleEndian, field)
+	}
+
+	table := func(text func(p pair) string) {
+		for _, p := range pairs {
+			s := text(p)
+			_ = binary.Write(&buf, binary.LittleEndian, []uint32{
+				uint32(len(s)),              //nolint:gosec // ok, bounded by the message file
+				offset + uint32(strs.Len()), //nolint:gosec // ok, bounded by the message file
+			})
+			strs.WriteString(s)
+			strs.WriteByte(0)
+		}
+	}
+
+	table(func(p pair) string { return p.key })
+	table(func(p pair) string { return p.value })
+	buf.Write(strs.Bytes())
+
+	return buf.Bytes()
+}
+
+// decodeMO reads the messages of a mo file, in either byte order.
+func decodeMO(data []byte) ([]message, error) {
+	if len(data) < moHeaderSize {
+		return nil, errors.New("invalid mo file: too short")
+	}
+
+	var order binary.ByteOrder
+
+	switch {
+	case binary.LittleEndian.Uint32(data) == moMagic:
+		order = binary.LittleEndian
+	case binary.BigEndian.Uint32(data) == moMagic:
+		order = binary.BigEndian
+	default:
+		return nil, errors.New("invalid mo file: bad magic number")
+	}
+
+	count := order.Uint32(data[8:])
+	originals := order.Uint32(data[12:])
+	translations := order.Uint32(data[16:])
+
+	read := func(table, i uint32) (string, error) {
+		entry := uint64(table) + uint64(i)*8
+		if entry+8 > uint64(len(data)) {
+			return "", errors.New("invalid mo file: table out of range")
+		}
+
+		length := uint64(order.Uint32(data[entry:]))
+		offset := uint64(order.Uint32(data[entry+4:]))
+
+		if offset+length > uint64(len(data)) {
+			return "", errors.New("invalid mo file: string out of range")
+		}
+
+		return string(data[offset : offset+length]), nil
+	}
+
+	messages := make([]message, 0, count)
+
+	for i := range count {
+		key, err := read(originals, i)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := read(translations, i)
+		if err != nil {
+			return nil, err
+		}
+
+		var m message
+
+		if context, id, found := strings.Cut(key, contextSeparator); found {
+			m.context, key = context, id
+		}
+
+		m.id, m.plural, m.isPlural = strings.Cut(key, pluralSeparator)
+		m.translations = strings.Split(value, pluralSeparator)
+
+		messages = append(messages, m)
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,98 @@
+package gettext
+
+import (
+	"fmt"
+	"slices"
+
+	"golang.org/x/text/language"
+)
+
+var (
+	// englishRules are the rules of the many languages whose only plural
+	// category, besides other, is one, for exactly 1
+	englishRules = map[string]string{
+		"one": "n == 1",
+	}
+
+	// slavicRules are the rules shared by the east Slavic languages
+	slavicRules = map[string]string{
+		"one":  "n % 10 == 1 && n % 100 != 11",
+		"few":  "n % 10 >= 2 && n % 10 <= 4 && (n % 100 < 12 || n % 100 > 14)",
+		"many": "n % 10 == 0 || (n % 10 >= 5 && n % 10 <= 9) || (n % 100 >= 11 && n % 100 <= 14)",
+	}
+
+	// westSlavicRules are the rules shared by Czech and Slovak
+	westSlavicRules = map[string]string{
+		"one": "n == 1",
+		"few": "n >= 2 && n <= 4",
+	}
+
+	// pluralRules are the CLDR cardinal plural rules of integers, as C
+	// expressions of n, for each category of a language other than other,
+	// keyed by the base language. A category that does not apply to integers,
+	// such as the many of Czech, is absent. A language without an entry only
+	// has the other category.
+	pluralRules = map[string]map[string]string{
+		"en": englishRules, "de": englishRules, "nl": englishRules,
+		"sv": englishRules, "da": englishRules, "nb": englishRules,
+		"no": englishRules, "fi": englishRules, "et": englishRules,
+		"it": englishRules, "es": englishRules, "ca": englishRules,
+		"el": englishRules, "hu": englishRules, "tr": englishRules,
+		"bg": englishRules,
+		"fr": {"one": "n == 0 || n == 1"},
+		"pt": {"one": "n == 0 || n == 1"},
+		"ru": slavicRules, "uk": slavicRules, "be": slavicRules,
+		"cs": westSlavicRules, "sk": westSlavicRules,
+		"pl": {
+			"one":  "n == 1",
+			"few":  "n % 10 >= 2 && n % 10 <= 4 && (n % 100 < 12 || n % 100 > 14)",
+			"many": "(n != 1 && n % 10 <= 1) || (n % 10 >= 5 && n % 10 <= 9) || (n % 100 >= 12 && n % 100 <= 14)",
+		},
+		"lt": {
+			"one": "n % 10 == 1 && (n % 100 < 11 || n % 100 > 19)",
+			"few": "n % 10 >= 2 && n % 10 <= 9 && (n % 100 < 11 || n % 100 > 19)",
+		},
+		"ar": {
+			"zero": "n == 0",
+			"one":  "n == 1",
+			"two":  "n == 2",
+			"few":  "n % 100 >= 3 && n % 100 <= 10",
+			"many": "n % 100 >= 11 && n % 100 <= 99",
+		},
+		"ja": {}, "zh": {}, "ko": {}, "vi": {}, "th": {}, "id": {},
+	}
+)
+
+// pluralForms returns the value of the Plural-Forms header, whose plural
+// expression selects the index of the used category that applies to n, as
+// derived from the CLDR rules of the language. The header can not be derived
+// for a language without known rules, other than for the default categories,
+// which are assumed to be those of English.
+func pluralForms(tag string, used []string) (string, bool) {
+	rules, found := map[string]string(nil), false
+
+	if parsed, err := language.Parse(tag); err == nil {
+		base, _ := parsed.Base()
+		rules, found = pluralRules[base.String()]
+	}
+
+	if !found {
+		if !slices.Equal(used, defaultCategories) {
+			return "", false
+		}
+
+		rules = englishRules
+	}
+
+	// categories that do not apply to integers are never selected, but still
+	// occupy an index, so that each msgstr[n] remains that of its category
+	expression := fmt.Sprint(slices.Index(used, "other"))
+
+	for i := len(used) - 1; i >= 0; i-- {
+		if rule, found := rules[used[i]]; found {
+			expression = fmt.Sprintf("(%v) ? %v : %v", rule, i, expression)
+		}
+	}
+
+	return fmt.Sprintf("nplurals=%v; plural=(%v);", len(used), expression), true
+}
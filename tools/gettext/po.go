@@ -0,0 +1,225 @@
+package gettext
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/snivilised/li18ngo/internal/third/lo"
+)
+
+// ExportPO converts a go-i18n message file to a gettext po file. The message
+// id is carried by the msgctxt, the description by translator comments and
+// the hash by a flag (hash=...), so that the message file can be recreated
+// without loss by ImportPO.
+func ExportPO(data []byte, options Options) ([]byte, error) {
+	messages, err := fromJSON(data, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodePO(messages), nil
+}
+
+// ImportPO converts a gettext po file to a go-i18n message file.
+func ImportPO(data []byte) ([]byte, error) {
+	messages, err := decodePO(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return toJSON(messages)
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\t", `\t`,
+	"\r", `\r`,
+)
+
+func encodePO(messages []message) []byte {
+	var buf bytes.Buffer
+
+	for i, m := range messages {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+
+		for _, comment := range m.comments {
+			buf.WriteString(lo.Ternary(comment == "", "#", "# "+comment) + "\n")
+		}
+
+		if len(m.flags) > 0 {
+			fmt.Fprintf(&buf, "#, %v\n", strings.Join(m.flags, ", "))
+		}
+
+		if m.context != "" {
+			writeString(&buf, "msgctxt", m.context)
+		}
+
+		writeString(&buf, "msgid", m.id)
+
+		if m.isPlural {
+			writeString(&buf, "msgid_plural", m.plural)
+
+			for n, translation := range m.translations {
+				writeString(&buf, fmt.Sprintf("msgstr[%v]", n), translation)
+			}
+
+			continue
+		}
+
+		writeString(&buf, "msgstr", m.translations[0])
+	}
+
+	return buf.Bytes()
+}
+
+// writeString writes the keyword and its string, which is split over multiple
+// lines after each new line it contains.
+func writeString(buf *bytes.Buffer, keyword, text string) {
+	lines := strings.SplitAfter(text, "\n")
+
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 1 {
+		fmt.Fprintf(buf, "%v \"%v\"\n", keyword, escaper.Replace(text))
+
+		return
+	}
+
+	fmt.Fprintf(buf, "%v \"\"\n", keyword)
+
+	for _, line := range lines {
+		fmt.Fprintf(buf, "\"%v\"\n", escaper.Replace(line))
+	}
+}
+
+// decodePO parses the messages of a po file. Obsolete messages (#~) and
+// comments other than translator comments and flags are ignored.
+func decodePO(data []byte) ([]message, error) {
+	var (
+		messages []message
+		current  message
+		started  bool
+		appendTo func(text string)
+	)
+
+	flush := func() {
+		if started {
+			messages = append(messages, current)
+		}
+
+		current = message{}
+		started = false
+		appendTo = nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), len(data)+1)
+
+	for number := 1; scanner.Scan(); number++ {
+		raw := strings.TrimLeft(strings.TrimSuffix(scanner.Text(), "\r"), " \t")
+		line := strings.TrimSpace(raw)
+
+		switch {
+		case line == "":
+			flush()
+
+		case strings.HasPrefix(line, "#"):
+			// a comment following the translations begins the next message
+			if len(current.translations) > 0 {
+				flush()
+			}
+
+			switch {
+			case line == "#":
+				current.comments = append(current.comments, "")
+			case strings.HasPrefix(raw, "# "):
+				current.comments = append(current.comments, raw[2:])
+			case strings.HasPrefix(line, "#,"):
+				for flag := range strings.SplitSeq(line[2:], ",") {
+					current.flags = append(current.flags, strings.TrimSpace(flag))
+				}
+			}
+
+			appendTo = nil
+
+		case strings.HasPrefix(line, `"`):
+			text, err := strconv.Unquote(line)
+			if err != nil || appendTo == nil {
+				return nil, fmt.Errorf("line %v: unexpected string: %v", number, line)
+			}
+
+			appendTo(text)
+
+		default:
+			keyword, quoted, _ := strings.Cut(line, " ")
+
+			text, err := strconv.Unquote(strings.TrimSpace(quoted))
+			if err != nil {
+				return nil, fmt.Errorf("line %v: invalid string: %v", number, line)
+			}
+
+			// a message not separated from the previous by a blank line
+			if (keyword == "msgctxt" || keyword == "msgid") && len(current.translations) > 0 {
+				flush()
+			}
+
+			started = true
+
+			if appendTo, err = current.field(keyword); err != nil {
+				return nil, fmt.Errorf("line %v: %w", number, err)
+			}
+
+			appendTo(text)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	flush()
+
+	return messages, nil
+}
+
+// field returns the function that appends text to the field of the message
+// denoted by the keyword.
+func (m *message) field(keyword string) (func(text string), error) {
+	switch keyword {
+	case "msgctxt":
+		return func(text string) { m.context += text }, nil
+
+	case "msgid":
+		return func(text string) { m.id += text }, nil
+
+	case "msgid_plural":
+		m.isPlural = true
+
+		return func(text string) { m.plural += text }, nil
+
+	case "msgstr":
+		m.translations = append(m.translations, "")
+
+		return func(text string) { m.translations[0] += text }, nil
+	}
+
+	var n int
+	if _, err := fmt.Sscanf(keyword, "msgstr[%d]", &n); err != nil || n < 0 {
+		return nil, fmt.Errorf("unknown keyword: %v", keyword)
+	}
+
+	for len(m.translations) <= n {
+		m.translations = append(m.translations, "")
+	}
+
+	return func(text string) { m.translations[n] += text }, nil
+}
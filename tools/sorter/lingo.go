@@ -13,6 +13,13 @@ import (
 type MessageEntry struct {
 	Description string `json:"description"`
 	Other       string `json:"other"`
+
+	// plural forms, present only for messages that have them
+	Zero string `json:"zero,omitempty"`
+	One  string `json:"one,omitempty"`
+	Two  string `json:"two,omitempty"`
+	Few  string `json:"few,omitempty"`
+	Many string `json:"many,omitempty"`
 }
 
 type HashedMessageEntry struct {
@@ -20,6 +27,50 @@ type HashedMessageEntry struct {
 	Hash string `json:"hash"`
 }
 
+// Categories are the CLDR plural categories, in canonical order
+var Categories = []string{"zero", "one", "two", "few", "many", "other"}
+
+// IsPlural determines whether the message has plural forms
+func (e *MessageEntry) IsPlural() bool {
+	return e.Zero != "" || e.One != "" || e.Two != "" || e.Few != "" || e.Many != ""
+}
+
+// Form returns the text of the message for the plural category
+func (e *MessageEntry) Form(category string) string {
+	switch category {
+	case "zero":
+		return e.Zero
+	case "one":
+		return e.One
+	case "two":
+		return e.Two
+	case "few":
+		return e.Few
+	case "many":
+		return e.Many
+	}
+
+	return e.Other
+}
+
+// SetForm sets the text of the message for the plural category
+func (e *MessageEntry) SetForm(category, text string) {
+	switch category {
+	case "zero":
+		e.Zero = text
+	case "one":
+		e.One = text
+	case "two":
+		e.Two = text
+	case "few":
+		e.Few = text
+	case "many":
+		e.Many = text
+	default:
+		e.Other = text
+	}
+}
+
 func Apply[T MessageEntry | HashedMessageEntry](data []byte) ([]byte, error) {
 	var messages map[string]T
 	if err := json.Unmarshal(data, &messages); err != nil {
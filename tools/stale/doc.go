@@ -0,0 +1,5 @@
+// Package stale detects translations whose source text has changed since
+// they were translated, by comparing the hash recorded in a translation
+// message file with that of the current source message, and optionally marks
+// them in the file, so that translators know what to revisit.
+package stale
@@ -0,0 +1,29 @@
+package stale
+
+// 📚 pkg: stale - detects translations whose source has changed
+
+const (
+	// Marker prefixes the recorded hash of a translation that has been
+	// marked stale. The hash remains that of the source that was translated,
+	// so the translation is not mistaken for being current, by either
+	// li18ngo or goi18n, until it is revised and given the current hash.
+	Marker = "stale-"
+)
+
+type (
+	// Translation is a translation whose source has changed since it was
+	// translated.
+	Translation struct {
+		// ID is the message id
+		ID string
+
+		// Recorded is the hash recorded in the translation file
+		Recorded string
+
+		// Current is the hash of the current source message
+		Current string
+
+		// Marked denotes a translation already marked stale in the file
+		Marked bool
+	}
+)
@@ -0,0 +1,13 @@
+package stale_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestStale(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Stale Suite")
+}
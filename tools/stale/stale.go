@@ -0,0 +1,83 @@
+package stale
+
+import (
+	"crypto/sha1" //nolint:gosec // sha1 is required for compatibility with goi18n hashes
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/snivilised/li18ngo/tools/sorter"
+)
+
+// Hash returns the hash of the source message, computed from its description
+// and other text in the same manner as goi18n, so that translation files
+// produced by either are interchangeable.
+func Hash(source sorter.MessageEntry) string {
+	h := sha1.New() //nolint:gosec // see import
+	_, _ = h.Write([]byte(source.Description))
+	_, _ = h.Write([]byte(source.Other))
+
+	return fmt.Sprintf("sha1-%x", h.Sum(nil))
+}
+
+// Detect returns the translations of the message file (go-i18n json), ordered
+// by message id, whose recorded hash differs from the hash of the source
+// message of the same id, or which have already been marked stale. Messages
+// without a recorded hash, or without a source message, are not reported, as
+// there is nothing to compare them with.
+func Detect(sources map[string]sorter.MessageEntry, data []byte) ([]Translation, error) {
+	var translations map[string]sorter.HashedMessageEntry
+
+	if err := json.Unmarshal(data, &translations); err != nil {
+		return nil, err
+	}
+
+	var stale []Translation
+
+	for _, id := range slices.Sorted(maps.Keys(translations)) {
+		recorded := translations[id].Hash
+		source, found := sources[id]
+
+		if recorded == "" || !found {
+			continue
+		}
+
+		current := Hash(source)
+		marked := strings.HasPrefix(recorded, Marker)
+
+		if marked || recorded != current {
+			stale = append(stale, Translation{
+				ID:       id,
+				Recorded: recorded,
+				Current:  current,
+				Marked:   marked,
+			})
+		}
+	}
+
+	return stale, nil
+}
+
+// Mark returns the message file (go-i18n json), with the hash of each of the
+// stale translations not already marked, prefixed by the Marker.
+func Mark(data []byte, stale []Translation) ([]byte, error) {
+	var translations map[string]sorter.HashedMessageEntry
+
+	if err := json.Unmarshal(data, &translations); err != nil {
+		return nil, err
+	}
+
+	for _, t := range stale {
+		entry, found := translations[t.ID]
+		if !found || entry.Hash == "" || strings.HasPrefix(entry.Hash, Marker) {
+			continue
+		}
+
+		entry.Hash = Marker + entry.Hash
+		translations[t.ID] = entry
+	}
+
+	return json.MarshalIndent(translations, "", "  ")
+}
@@ -0,0 +1,141 @@
+package stale_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/snivilised/li18ngo/tools/sorter"
+	"github.com/snivilised/li18ngo/tools/stale"
+)
+
+const (
+	// localisationHash is the hash goi18n records for the localisation
+	// message, whose source is unchanged
+	localisationHash = "sha1-053e15971b8d428c47cdb902f90c4fcecc72e253"
+
+	// internationalisationHash is the hash goi18n recorded for the
+	// internationalisation message, before its source was changed
+	internationalisationHash = "sha1-8dd7952545d8104150f6d66d3e0f3c650b44c072"
+
+	translations = `{
+  "internationalisation.test": {
+    "description": "Internationalisation",
+    "hash": "sha1-8dd7952545d8104150f6d66d3e0f3c650b44c072",
+    "other": "internationalisation (fr)"
+  },
+  "localisation.test": {
+    "description": "Localisation",
+    "hash": "sha1-053e15971b8d428c47cdb902f90c4fcecc72e253",
+    "other": "localisation (fr)"
+  },
+  "unhashed.test": {
+    "description": "Unhashed",
+    "hash": "",
+    "other": "unhashed (fr)"
+  },
+  "removed.test": {
+    "description": "Removed",
+    "hash": "sha1-0000000000000000000000000000000000000000",
+    "other": "removed (fr)"
+  }
+}`
+)
+
+var _ = Describe("Stale", func() {
+	var sources map[string]sorter.MessageEntry
+
+	BeforeEach(func() {
+		sources = map[string]sorter.MessageEntry{
+			"internationalisation.test": {
+				Description: "Internationalisation",
+				Other:       "internationalisation, revised",
+			},
+			"localisation.test": {
+				Description: "Localisation",
+				Other:       "localisation",
+			},
+			"unhashed.test": {
+				Description: "Unhashed",
+				Other:       "unhashed",
+			},
+		}
+	})
+
+	Context("Hash", func() {
+		It("🧪 should: hash description and other, as goi18n", func() {
+			Expect(stale.Hash(sources["localisation.test"])).To(Equal(localisationHash))
+			Expect(stale.Hash(sorter.MessageEntry{
+				Description: "Internationalisation",
+				Other:       "internationalisation",
+			})).To(Equal(internationalisationHash))
+		})
+
+		It("🧪 should: ignore plural forms other than other", func() {
+			source := sources["localisation.test"]
+			source.One = "one localisation"
+
+			Expect(stale.Hash(source)).To(Equal(localisationHash))
+		})
+	})
+
+	Context("Detect", func() {
+		It("🧪 should: report translation whose source has changed", func() {
+			detected, err := stale.Detect(sources, []byte(translations))
+			Expect(err).To(Succeed())
+
+			Expect(detected).To(Equal([]stale.Translation{
+				{
+					ID:       "internationalisation.test",
+					Recorded: internationalisationHash,
+					Current:  stale.Hash(sources["internationalisation.test"]),
+				},
+			}), "unhashed and removed messages should not be reported")
+		})
+
+		It("🧪 should: report translation already marked", func() {
+			marked, err := stale.Mark([]byte(translations), []stale.Translation{
+				{ID: "localisation.test"},
+			})
+			Expect(err).To(Succeed())
+
+			detected, err := stale.Detect(sources, marked)
+			Expect(err).To(Succeed())
+
+			Expect(detected).To(ContainElement(stale.Translation{
+				ID:       "localisation.test",
+				Recorded: stale.Marker + localisationHash,
+				Current:  localisationHash,
+				Marked:   true,
+			}), "marked translation should be reported even though its source is unchanged")
+		})
+	})
+
+	Context("Mark", func() {
+		It("🧪 should: prefix hash of stale translation once", func() {
+			detected, err := stale.Detect(sources, []byte(translations))
+			Expect(err).To(Succeed())
+
+			marked, err := stale.Mark([]byte(translations), detected)
+			Expect(err).To(Succeed())
+
+			detected, err = stale.Detect(sources, marked)
+			Expect(err).To(Succeed())
+
+			again, err := stale.Mark(marked, detected)
+			Expect(err).To(Succeed())
+
+			var messages map[string]sorter.HashedMessageEntry
+			Expect(json.Unmarshal(again, &messages)).To(Succeed())
+
+			Expect(messages["internationalisation.test"].Hash).To(
+				Equal(stale.Marker + internationalisationHash),
+			)
+			Expect(messages["localisation.test"].Hash).To(Equal(localisationHash),
+				"current translation should not be marked",
+			)
+			Expect(messages["unhashed.test"].Hash).To(BeEmpty())
+		})
+	})
+})
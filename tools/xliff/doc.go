@@ -0,0 +1,4 @@
+// Package xliff exports the messages awaiting translation to XLIFF 2.0, for
+// hand-off to translators, and imports the completed translations back into
+// li18ngo translation message files.
+package xliff
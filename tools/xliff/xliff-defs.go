@@ -0,0 +1,100 @@
+package xliff
+
+import "encoding/xml"
+
+// 📚 pkg: xliff - converts message files to and from XLIFF 2.0
+
+const (
+	// Namespace is the namespace of XLIFF 2.0 documents
+	Namespace = "urn:oasis:names:tc:xliff:document:2.0"
+
+	// MetadataNamespace is the namespace of the XLIFF 2.0 metadata module
+	MetadataNamespace = "urn:oasis:names:tc:xliff:metadata:2.0"
+
+	// version of XLIFF produced
+	version = "2.0"
+
+	// category of the metadata and notes produced by li18ngo
+	category = "li18ngo"
+
+	// hashType is the type of the meta that carries the hash of a message
+	hashType = "hash"
+
+	// descriptionCategory is the category of the note that carries the
+	// description of a message
+	descriptionCategory = "description"
+)
+
+type (
+	// Options controls the export of messages to XLIFF.
+	Options struct {
+		// SourceLanguage is the language of the messages, ie the base language
+		SourceLanguage string
+
+		// TargetLanguage is the language the messages are to be translated into
+		TargetLanguage string
+
+		// File identifies the file element of the document, which defaults to
+		// "messages"
+		File string
+
+		// Active is the content of the translation file for the target language,
+		// (<name>.active.<lang>.json). When specified, messages that are
+		// already translated with the same hash are not exported.
+		Active []byte
+	}
+
+	document struct {
+		XMLName        xml.Name `xml:"urn:oasis:names:tc:xliff:document:2.0 xliff"`
+		Version        string   `xml:"version,attr"`
+		SourceLanguage string   `xml:"srcLang,attr"`
+		TargetLanguage string   `xml:"trgLang,attr,omitempty"`
+		Files          []file   `xml:"file"`
+	}
+
+	file struct {
+		ID    string `xml:"id,attr"`
+		Units []unit `xml:"unit"`
+	}
+
+	// unit is a message; a message with plural forms has a segment per plural
+	// category, identified by the category.
+	unit struct {
+		ID       string    `xml:"id,attr"`
+		Metadata *metadata `xml:"urn:oasis:names:tc:xliff:metadata:2.0 metadata,omitempty"`
+		Notes    *notes    `xml:"notes,omitempty"`
+		Segments []segment `xml:"segment"`
+	}
+
+	// metadata is in the namespace of the metadata module, which its
+	// elements inherit
+	metadata struct {
+		Groups []metaGroup `xml:"metaGroup"`
+	}
+
+	metaGroup struct {
+		Category string `xml:"category,attr,omitempty"`
+		Metas    []meta `xml:"meta"`
+	}
+
+	meta struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:",chardata"`
+	}
+
+	notes struct {
+		Notes []note `xml:"note"`
+	}
+
+	note struct {
+		Category string `xml:"category,attr,omitempty"`
+		Text     string `xml:",chardata"`
+	}
+
+	segment struct {
+		ID     string `xml:"id,attr,omitempty"`
+		State  string `xml:"state,attr,omitempty"`
+		Source string `xml:"source"`
+		Target string `xml:"target,omitempty"`
+	}
+)
@@ -0,0 +1,13 @@
+package xliff_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestXliff(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Xliff Suite")
+}
@@ -0,0 +1,219 @@
+package xliff
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"slices"
+
+	"github.com/snivilised/li18ngo/internal/third/lo"
+	"github.com/snivilised/li18ngo/tools/mio"
+	"github.com/snivilised/li18ngo/tools/sorter"
+)
+
+// Export writes the messages of a translate file (translate.<lang>.json), as
+// produced by the merge step, read from the input transport, to the output
+// transport as an XLIFF 2.0 document. The description of each message is
+// exported as a note and its hash as metadata.
+func Export(input, output mio.Transport, options Options) error {
+	data, err := input.Read()
+	if err != nil {
+		return fmt.Errorf("error reading from transport: '%v': %w", input.Address(), err)
+	}
+
+	exported, err := export(data, options)
+	if err != nil {
+		return fmt.Errorf("error exporting: '%v': %w", input.Address(), err)
+	}
+
+	if err := output.Write(exported); err != nil {
+		return fmt.Errorf("error writing to transport: '%v': %w", output.Address(), err)
+	}
+
+	return nil
+}
+
+// Import merges the units of the XLIFF document read from the input
+// transport, whose translation is complete, into the translation file
+// (<name>.active.<lang>.json) of the active transport, preserving the hashes
+// of the messages. Messages of the translation file that are not in the
+// document are retained and a translation file that does not yet exist is
+// created.
+func Import(input, active mio.Transport) error {
+	data, err := input.Read()
+	if err != nil {
+		return fmt.Errorf("error reading from transport: '%v': %w", input.Address(), err)
+	}
+
+	existing, err := active.Read()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error reading from transport: '%v': %w", active.Address(), err)
+	}
+
+	merged, err := merge(data, existing)
+	if err != nil {
+		return fmt.Errorf("error importing: '%v': %w", input.Address(), err)
+	}
+
+	if err := active.Write(merged); err != nil {
+		return fmt.Errorf("error writing to transport: '%v': %w", active.Address(), err)
+	}
+
+	return nil
+}
+
+// export converts the messages to an XLIFF document, with a unit for each
+// message not already translated.
+func export(data []byte, options Options) ([]byte, error) {
+	var messages, translated map[string]sorter.HashedMessageEntry
+
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, err
+	}
+
+	if options.Active != nil {
+		if err := json.Unmarshal(options.Active, &translated); err != nil {
+			return nil, err
+		}
+	}
+
+	units := make([]unit, 0, len(messages))
+
+	for _, id := range slices.Sorted(maps.Keys(messages)) {
+		entry := messages[id]
+
+		if existing, found := translated[id]; found && existing.Hash == entry.Hash {
+			continue
+		}
+
+		units = append(units, toUnit(id, &entry))
+	}
+
+	doc := document{
+		Version:        version,
+		SourceLanguage: options.SourceLanguage,
+		TargetLanguage: options.TargetLanguage,
+		Files: []file{
+			{
+				ID:    lo.Ternary(options.File == "", "messages", options.File),
+				Units: units,
+			},
+		},
+	}
+
+	content, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte(xml.Header), content...), '\n'), nil
+}
+
+// merge adds the completed units of the XLIFF document to the messages of
+// the translation file.
+func merge(data, existing []byte) ([]byte, error) {
+	var doc document
+
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]sorter.HashedMessageEntry)
+
+	if existing != nil {
+		if err := json.Unmarshal(existing, &entries); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range doc.Files {
+		for _, u := range f.Units {
+			if entry, complete := fromUnit(&u); complete {
+				entries[u.ID] = entry
+			}
+		}
+	}
+
+	return json.MarshalIndent(entries, "", "  ")
+}
+
+// toUnit converts the message to a unit awaiting translation
+func toUnit(id string, entry *sorter.HashedMessageEntry) unit {
+	u := unit{
+		ID: id,
+	}
+
+	if entry.Hash != "" {
+		u.Metadata = &metadata{
+			Groups: []metaGroup{
+				{
+					Category: category,
+					Metas:    []meta{{Type: hashType, Value: entry.Hash}},
+				},
+			},
+		}
+	}
+
+	if entry.Description != "" {
+		u.Notes = &notes{
+			Notes: []note{{Category: descriptionCategory, Text: entry.Description}},
+		}
+	}
+
+	if !entry.IsPlural() {
+		u.Segments = []segment{{State: "initial", Source: entry.Other}}
+
+		return u
+	}
+
+	for _, c := range sorter.Categories {
+		if text := entry.Form(c); text != "" {
+			u.Segments = append(u.Segments, segment{ID: c, State: "initial", Source: text})
+		}
+	}
+
+	return u
+}
+
+// fromUnit converts the unit to a translated message, indicating whether
+// each of its segments has been translated.
+func fromUnit(u *unit) (sorter.HashedMessageEntry, bool) {
+	var entry sorter.HashedMessageEntry
+
+	if u.Notes != nil {
+		for _, n := range u.Notes.Notes {
+			if n.Category == descriptionCategory {
+				entry.Description = n.Text
+			}
+		}
+	}
+
+	if u.Metadata != nil {
+		for _, group := range u.Metadata.Groups {
+			for _, m := range group.Metas {
+				if m.Type == hashType {
+					entry.Hash = m.Value
+				}
+			}
+		}
+	}
+
+	for _, s := range u.Segments {
+		if s.Target == "" {
+			return entry, false
+		}
+
+		// the segments of a message without plural forms, may have been
+		// split by the translator's tool, in which case they are rejoined
+		if slices.Contains(sorter.Categories, s.ID) {
+			entry.SetForm(s.ID, s.Target)
+		} else {
+			entry.Other += s.Target
+		}
+	}
+
+	return entry, len(u.Segments) > 0
+}
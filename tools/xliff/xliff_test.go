@@ -0,0 +1,202 @@
+package xliff_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/snivilised/li18ngo/tools/mio"
+	"github.com/snivilised/li18ngo/tools/sorter"
+	"github.com/snivilised/li18ngo/tools/xliff"
+)
+
+const (
+	pending = `{
+  "files-found.plural": {
+    "description": "Number of files found",
+    "hash": "sha1-6a5d2c1e7f0b3a9d8c4e2f1a0b9c8d7e6f5a4b3c",
+    "other": "{{.Count}} files found",
+    "one": "{{.Count}} file found"
+  },
+  "localisation.test": {
+    "description": "Localisation",
+    "hash": "sha1-053e15971b8d428c47cdb902f90c4fcecc72e253",
+    "other": "localisation"
+  }
+}`
+
+	active = `{
+  "internationalisation.test": {
+    "description": "Internationalisation",
+    "hash": "sha1-8dd7952545d8104150f6d66d3e0f3c650b44c072",
+    "other": "internationalisation"
+  },
+  "localisation.test": {
+    "description": "Localisation",
+    "hash": "sha1-053e15971b8d428c47cdb902f90c4fcecc72e253",
+    "other": "localisation"
+  }
+}`
+)
+
+var _ = Describe("Xliff", func() {
+	var (
+		directory string
+		input     *mio.NativeReaderWriterFS
+		document  *mio.NativeReaderWriterFS
+		target    *mio.NativeReaderWriterFS
+	)
+
+	// translate completes the segments of the document, whose source text is
+	// a key of the translations
+	translate := func(translations map[string]string) {
+		data, err := document.Read()
+		Expect(err).To(Succeed())
+
+		pairs := make([]string, 0, len(translations)*2)
+		for source, translation := range translations {
+			pairs = append(pairs, "<source>"+source+"</source>",
+				"<source>"+source+"</source><target>"+translation+"</target>",
+			)
+		}
+
+		Expect(document.Write([]byte(strings.NewReplacer(pairs...).Replace(string(data))))).To(Succeed())
+	}
+
+	imported := func() map[string]sorter.HashedMessageEntry {
+		data, err := target.Read()
+		Expect(err).To(Succeed())
+
+		var messages map[string]sorter.HashedMessageEntry
+		Expect(json.Unmarshal(data, &messages)).To(Succeed())
+
+		return messages
+	}
+
+	BeforeEach(func() {
+		directory = GinkgoT().TempDir()
+		input = &mio.NativeReaderWriterFS{Path: filepath.Join(directory, "translate.fr.json")}
+		document = &mio.NativeReaderWriterFS{Path: filepath.Join(directory, "translate.fr.xlf")}
+		target = &mio.NativeReaderWriterFS{Path: filepath.Join(directory, "test.active.fr.json")}
+
+		Expect(input.Write([]byte(pending))).To(Succeed())
+		Expect(xliff.Export(input, document, xliff.Options{
+			SourceLanguage: "en-GB",
+			TargetLanguage: "fr",
+		})).To(Succeed())
+	})
+
+	Context("Export", func() {
+		It("🧪 should: export description as note and hash as metadata", func() {
+			data, err := document.Read()
+			Expect(err).To(Succeed())
+
+			content := string(data)
+			Expect(content).To(ContainSubstring(`srcLang="en-GB" trgLang="fr"`))
+			Expect(content).To(ContainSubstring(
+				`<meta type="hash">sha1-053e15971b8d428c47cdb902f90c4fcecc72e253</meta>`,
+			))
+			Expect(content).To(ContainSubstring(`<note category="description">Localisation</note>`))
+		})
+
+		It("🧪 should: export a segment per plural category", func() {
+			data, err := document.Read()
+			Expect(err).To(Succeed())
+
+			content := string(data)
+			Expect(content).To(ContainSubstring(
+				"<segment id=\"one\" state=\"initial\">\n        <source>{{.Count}} file found</source>",
+			))
+			Expect(content).To(ContainSubstring(
+				"<segment id=\"other\" state=\"initial\">\n        <source>{{.Count}} files found</source>",
+			))
+		})
+
+		When("active translation file is specified", func() {
+			It("🧪 should: not export messages already translated with the same hash", func() {
+				Expect(xliff.Export(input, document, xliff.Options{
+					SourceLanguage: "en-GB",
+					TargetLanguage: "fr",
+					Active:         []byte(active),
+				})).To(Succeed())
+
+				data, err := document.Read()
+				Expect(err).To(Succeed())
+
+				content := string(data)
+				Expect(content).To(ContainSubstring(`<unit id="files-found.plural">`))
+				Expect(content).NotTo(ContainSubstring(`<unit id="localisation.test">`))
+			})
+		})
+	})
+
+	Context("Import", func() {
+		It("🧪 should: round-trip hash and plural forms", func() {
+			Expect(target.Write([]byte(active))).To(Succeed())
+			translate(map[string]string{
+				"localisation":           "localisation (fr)",
+				"{{.Count}} file found":  "{{.Count}} fichier trouvé",
+				"{{.Count}} files found": "{{.Count}} fichiers trouvés",
+			})
+
+			Expect(xliff.Import(document, target)).To(Succeed())
+
+			messages := imported()
+			Expect(messages).To(HaveLen(3), "message not in the document should be retained")
+			Expect(messages["localisation.test"]).To(Equal(sorter.HashedMessageEntry{
+				MessageEntry: sorter.MessageEntry{
+					Description: "Localisation",
+					Other:       "localisation (fr)",
+				},
+				Hash: "sha1-053e15971b8d428c47cdb902f90c4fcecc72e253",
+			}))
+			Expect(messages["files-found.plural"]).To(Equal(sorter.HashedMessageEntry{
+				MessageEntry: sorter.MessageEntry{
+					Description: "Number of files found",
+					Other:       "{{.Count}} fichiers trouvés",
+					One:         "{{.Count}} fichier trouvé",
+				},
+				Hash: "sha1-6a5d2c1e7f0b3a9d8c4e2f1a0b9c8d7e6f5a4b3c",
+			}))
+		})
+
+		It("🧪 should: skip units without a target", func() {
+			Expect(target.Write([]byte(active))).To(Succeed())
+			translate(map[string]string{
+				"{{.Count}} file found": "{{.Count}} fichier trouvé",
+			})
+
+			Expect(xliff.Import(document, target)).To(Succeed())
+
+			messages := imported()
+			Expect(messages).NotTo(HaveKey("files-found.plural"),
+				"partially translated plural message should be skipped",
+			)
+			Expect(messages["localisation.test"].Other).To(Equal("localisation"),
+				"untranslated message should not replace the existing translation",
+			)
+		})
+
+		When("active translation file does not exist", func() {
+			It("🧪 should: create it", func() {
+				translate(map[string]string{
+					"localisation": "localisation (fr)",
+				})
+
+				Expect(xliff.Import(document, target)).To(Succeed())
+
+				Expect(target.Path).To(BeAnExistingFile())
+				Expect(imported()).To(HaveKeyWithValue("localisation.test", sorter.HashedMessageEntry{
+					MessageEntry: sorter.MessageEntry{
+						Description: "Localisation",
+						Other:       "localisation (fr)",
+					},
+					Hash: "sha1-053e15971b8d428c47cdb902f90c4fcecc72e253",
+				}))
+			})
+		})
+	})
+})